@@ -9,6 +9,8 @@ package hookify
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,28 +23,3061 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SignatureScheme int32
+
+const (
+	SignatureScheme_SIGNATURE_SCHEME_UNSPECIFIED SignatureScheme = 0
+	SignatureScheme_SIGNATURE_SCHEME_STANDARD    SignatureScheme = 1
+	SignatureScheme_SIGNATURE_SCHEME_LEGACY      SignatureScheme = 2
+)
+
+// Enum value maps for SignatureScheme.
+var (
+	SignatureScheme_name = map[int32]string{
+		0: "SIGNATURE_SCHEME_UNSPECIFIED",
+		1: "SIGNATURE_SCHEME_STANDARD",
+		2: "SIGNATURE_SCHEME_LEGACY",
+	}
+	SignatureScheme_value = map[string]int32{
+		"SIGNATURE_SCHEME_UNSPECIFIED": 0,
+		"SIGNATURE_SCHEME_STANDARD":    1,
+		"SIGNATURE_SCHEME_LEGACY":      2,
+	}
+)
+
+func (x SignatureScheme) Enum() *SignatureScheme {
+	p := new(SignatureScheme)
+	*p = x
+	return p
+}
+
+func (x SignatureScheme) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SignatureScheme) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookify_proto_enumTypes[0].Descriptor()
+}
+
+func (SignatureScheme) Type() protoreflect.EnumType {
+	return &file_hookify_proto_enumTypes[0]
+}
+
+func (x SignatureScheme) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SignatureScheme.Descriptor instead.
+func (SignatureScheme) EnumDescriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{0}
+}
+
+type WebhookStatus int32
+
+const (
+	WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED WebhookStatus = 0
+	WebhookStatus_WEBHOOK_STATUS_ACTIVE      WebhookStatus = 1
+	WebhookStatus_WEBHOOK_STATUS_DISABLED    WebhookStatus = 2
+	WebhookStatus_WEBHOOK_STATUS_PAUSED      WebhookStatus = 3
+)
+
+// Enum value maps for WebhookStatus.
+var (
+	WebhookStatus_name = map[int32]string{
+		0: "WEBHOOK_STATUS_UNSPECIFIED",
+		1: "WEBHOOK_STATUS_ACTIVE",
+		2: "WEBHOOK_STATUS_DISABLED",
+		3: "WEBHOOK_STATUS_PAUSED",
+	}
+	WebhookStatus_value = map[string]int32{
+		"WEBHOOK_STATUS_UNSPECIFIED": 0,
+		"WEBHOOK_STATUS_ACTIVE":      1,
+		"WEBHOOK_STATUS_DISABLED":    2,
+		"WEBHOOK_STATUS_PAUSED":      3,
+	}
+)
+
+func (x WebhookStatus) Enum() *WebhookStatus {
+	p := new(WebhookStatus)
+	*p = x
+	return p
+}
+
+func (x WebhookStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookify_proto_enumTypes[1].Descriptor()
+}
+
+func (WebhookStatus) Type() protoreflect.EnumType {
+	return &file_hookify_proto_enumTypes[1]
+}
+
+func (x WebhookStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookStatus.Descriptor instead.
+func (WebhookStatus) EnumDescriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{1}
+}
+
+type EventStatus int32
+
+const (
+	EventStatus_EVENT_STATUS_UNSPECIFIED EventStatus = 0
+	EventStatus_EVENT_STATUS_PENDING     EventStatus = 1
+	EventStatus_EVENT_STATUS_DELIVERED   EventStatus = 2
+	EventStatus_EVENT_STATUS_FAILED      EventStatus = 3
+	EventStatus_EVENT_STATUS_FILTERED    EventStatus = 4
+)
+
+// Enum value maps for EventStatus.
+var (
+	EventStatus_name = map[int32]string{
+		0: "EVENT_STATUS_UNSPECIFIED",
+		1: "EVENT_STATUS_PENDING",
+		2: "EVENT_STATUS_DELIVERED",
+		3: "EVENT_STATUS_FAILED",
+		4: "EVENT_STATUS_FILTERED",
+	}
+	EventStatus_value = map[string]int32{
+		"EVENT_STATUS_UNSPECIFIED": 0,
+		"EVENT_STATUS_PENDING":     1,
+		"EVENT_STATUS_DELIVERED":   2,
+		"EVENT_STATUS_FAILED":      3,
+		"EVENT_STATUS_FILTERED":    4,
+	}
+)
+
+func (x EventStatus) Enum() *EventStatus {
+	p := new(EventStatus)
+	*p = x
+	return p
+}
+
+func (x EventStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookify_proto_enumTypes[2].Descriptor()
+}
+
+func (EventStatus) Type() protoreflect.EnumType {
+	return &file_hookify_proto_enumTypes[2]
+}
+
+func (x EventStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventStatus.Descriptor instead.
+func (EventStatus) EnumDescriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{2}
+}
+
+type DeadLetterSource int32
+
+const (
+	DeadLetterSource_DEAD_LETTER_SOURCE_UNSPECIFIED DeadLetterSource = 0
+	DeadLetterSource_DEAD_LETTER_SOURCE_OUTBOX      DeadLetterSource = 1
+	DeadLetterSource_DEAD_LETTER_SOURCE_CONSUMER    DeadLetterSource = 2
+)
+
+// Enum value maps for DeadLetterSource.
+var (
+	DeadLetterSource_name = map[int32]string{
+		0: "DEAD_LETTER_SOURCE_UNSPECIFIED",
+		1: "DEAD_LETTER_SOURCE_OUTBOX",
+		2: "DEAD_LETTER_SOURCE_CONSUMER",
+	}
+	DeadLetterSource_value = map[string]int32{
+		"DEAD_LETTER_SOURCE_UNSPECIFIED": 0,
+		"DEAD_LETTER_SOURCE_OUTBOX":      1,
+		"DEAD_LETTER_SOURCE_CONSUMER":    2,
+	}
+)
+
+func (x DeadLetterSource) Enum() *DeadLetterSource {
+	p := new(DeadLetterSource)
+	*p = x
+	return p
+}
+
+func (x DeadLetterSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeadLetterSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookify_proto_enumTypes[3].Descriptor()
+}
+
+func (DeadLetterSource) Type() protoreflect.EnumType {
+	return &file_hookify_proto_enumTypes[3]
+}
+
+func (x DeadLetterSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeadLetterSource.Descriptor instead.
+func (DeadLetterSource) EnumDescriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{3}
+}
+
+type RetryPolicy struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InitialInterval *durationpb.Duration   `protobuf:"bytes,1,opt,name=initial_interval,json=initialInterval,proto3" json:"initial_interval,omitempty"`
+	Multiplier      float64                `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	MaxInterval     *durationpb.Duration   `protobuf:"bytes,3,opt,name=max_interval,json=maxInterval,proto3" json:"max_interval,omitempty"`
+	Jitter          float64                `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	MaxAttempts     int32                  `protobuf:"varint,5,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	MaxAge          *durationpb.Duration   `protobuf:"bytes,6,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_hookify_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RetryPolicy) GetInitialInterval() *durationpb.Duration {
+	if x != nil {
+		return x.InitialInterval
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetMaxInterval() *durationpb.Duration {
+	if x != nil {
+		return x.MaxInterval
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.MaxAge
+	}
+	return nil
+}
+
+type Webhook struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url                     string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	CreatedAt               *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PreviousSecretExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=previous_secret_expires_at,json=previousSecretExpiresAt,proto3" json:"previous_secret_expires_at,omitempty"`
+	SignatureScheme         SignatureScheme        `protobuf:"varint,5,opt,name=signature_scheme,json=signatureScheme,proto3,enum=hookify.SignatureScheme" json:"signature_scheme,omitempty"`
+	PreviousApiKeyExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=previous_api_key_expires_at,json=previousApiKeyExpiresAt,proto3" json:"previous_api_key_expires_at,omitempty"`
+	RetryPolicy             *RetryPolicy           `protobuf:"bytes,7,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	Status                  WebhookStatus          `protobuf:"varint,8,opt,name=status,proto3,enum=hookify.WebhookStatus" json:"status,omitempty"`
+	DisabledReason          string                 `protobuf:"bytes,9,opt,name=disabled_reason,json=disabledReason,proto3" json:"disabled_reason,omitempty"`
+	Ordered                 bool                   `protobuf:"varint,10,opt,name=ordered,proto3" json:"ordered,omitempty"`
+	EventTypes              []string               `protobuf:"bytes,11,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	ApplicationId           int64                  `protobuf:"varint,12,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_hookify_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Webhook) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetPreviousSecretExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousSecretExpiresAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetSignatureScheme() SignatureScheme {
+	if x != nil {
+		return x.SignatureScheme
+	}
+	return SignatureScheme_SIGNATURE_SCHEME_UNSPECIFIED
+}
+
+func (x *Webhook) GetPreviousApiKeyExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousApiKeyExpiresAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *Webhook) GetStatus() WebhookStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+}
+
+func (x *Webhook) GetDisabledReason() string {
+	if x != nil {
+		return x.DisabledReason
+	}
+	return ""
+}
+
+func (x *Webhook) GetOrdered() bool {
+	if x != nil {
+		return x.Ordered
+	}
+	return false
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetApplicationId() int64 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
 type CreateWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ApplicationId int64                  `protobuf:"varint,2,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_hookify_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetApplicationId() int64 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,3,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_hookify_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateWebhookResponse) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *CreateWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateWebhookResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+type GetWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookRequest) Reset() {
+	*x = GetWebhookRequest{}
+	mi := &file_hookify_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookRequest) ProtoMessage() {}
+
+func (x *GetWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetWebhookRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+type GetWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookResponse) Reset() {
+	*x = GetWebhookResponse{}
+	mi := &file_hookify_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookResponse) ProtoMessage() {}
+
+func (x *GetWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_hookify_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListWebhooksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWebhooksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_hookify_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+func (x *ListWebhooksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateWebhookRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId        int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Url              *string                `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	SignatureScheme  SignatureScheme        `protobuf:"varint,3,opt,name=signature_scheme,json=signatureScheme,proto3,enum=hookify.SignatureScheme" json:"signature_scheme,omitempty"`
+	RetryPolicy      *RetryPolicy           `protobuf:"bytes,4,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	ClearRetryPolicy bool                   `protobuf:"varint,5,opt,name=clear_retry_policy,json=clearRetryPolicy,proto3" json:"clear_retry_policy,omitempty"`
+	Ordered          *bool                  `protobuf:"varint,6,opt,name=ordered,proto3,oneof" json:"ordered,omitempty"`
+	EventTypes       *EventTypes            `protobuf:"bytes,7,opt,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookRequest) Reset() {
+	*x = UpdateWebhookRequest{}
+	mi := &file_hookify_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookRequest) ProtoMessage() {}
+
+func (x *UpdateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateWebhookRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *UpdateWebhookRequest) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *UpdateWebhookRequest) GetSignatureScheme() SignatureScheme {
+	if x != nil {
+		return x.SignatureScheme
+	}
+	return SignatureScheme_SIGNATURE_SCHEME_UNSPECIFIED
+}
+
+func (x *UpdateWebhookRequest) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetClearRetryPolicy() bool {
+	if x != nil {
+		return x.ClearRetryPolicy
+	}
+	return false
+}
+
+func (x *UpdateWebhookRequest) GetOrdered() bool {
+	if x != nil && x.Ordered != nil {
+		return *x.Ordered
+	}
+	return false
+}
+
+func (x *UpdateWebhookRequest) GetEventTypes() *EventTypes {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type EventTypes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patterns      []string               `protobuf:"bytes,1,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventTypes) Reset() {
+	*x = EventTypes{}
+	mi := &file_hookify_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventTypes) ProtoMessage() {}
+
+func (x *EventTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventTypes.ProtoReflect.Descriptor instead.
+func (*EventTypes) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EventTypes) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+type UpdateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookResponse) Reset() {
+	*x = UpdateWebhookResponse{}
+	mi := &file_hookify_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookResponse) ProtoMessage() {}
+
+func (x *UpdateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_hookify_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_hookify_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{12}
+}
+
+type PauseWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseWebhookRequest) Reset() {
+	*x = PauseWebhookRequest{}
+	mi := &file_hookify_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseWebhookRequest) ProtoMessage() {}
+
+func (x *PauseWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseWebhookRequest.ProtoReflect.Descriptor instead.
+func (*PauseWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PauseWebhookRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+type PauseWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseWebhookResponse) Reset() {
+	*x = PauseWebhookResponse{}
+	mi := &file_hookify_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseWebhookResponse) ProtoMessage() {}
+
+func (x *PauseWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseWebhookResponse.ProtoReflect.Descriptor instead.
+func (*PauseWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PauseWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type ResumeWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeWebhookRequest) Reset() {
+	*x = ResumeWebhookRequest{}
+	mi := &file_hookify_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeWebhookRequest) ProtoMessage() {}
+
+func (x *ResumeWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeWebhookRequest.ProtoReflect.Descriptor instead.
+func (*ResumeWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResumeWebhookRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+type ResumeWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeWebhookResponse) Reset() {
+	*x = ResumeWebhookResponse{}
+	mi := &file_hookify_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeWebhookResponse) ProtoMessage() {}
+
+func (x *ResumeWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeWebhookResponse.ProtoReflect.Descriptor instead.
+func (*ResumeWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResumeWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type RotateWebhookSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	GracePeriod   *durationpb.Duration   `protobuf:"bytes,2,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateWebhookSecretRequest) Reset() {
+	*x = RotateWebhookSecretRequest{}
+	mi := &file_hookify_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateWebhookSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateWebhookSecretRequest) ProtoMessage() {}
+
+func (x *RotateWebhookSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateWebhookSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateWebhookSecretRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RotateWebhookSecretRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *RotateWebhookSecretRequest) GetGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracePeriod
+	}
+	return nil
+}
+
+type RotateWebhookSecretResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Secret                  string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	PreviousSecretExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=previous_secret_expires_at,json=previousSecretExpiresAt,proto3" json:"previous_secret_expires_at,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RotateWebhookSecretResponse) Reset() {
+	*x = RotateWebhookSecretResponse{}
+	mi := &file_hookify_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateWebhookSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateWebhookSecretResponse) ProtoMessage() {}
+
+func (x *RotateWebhookSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateWebhookSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateWebhookSecretResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RotateWebhookSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *RotateWebhookSecretResponse) GetPreviousSecretExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousSecretExpiresAt
+	}
+	return nil
+}
+
+type RotateWebhookApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	GracePeriod   *durationpb.Duration   `protobuf:"bytes,2,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateWebhookApiKeyRequest) Reset() {
+	*x = RotateWebhookApiKeyRequest{}
+	mi := &file_hookify_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateWebhookApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateWebhookApiKeyRequest) ProtoMessage() {}
+
+func (x *RotateWebhookApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateWebhookApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateWebhookApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RotateWebhookApiKeyRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *RotateWebhookApiKeyRequest) GetGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracePeriod
+	}
+	return nil
+}
+
+type RotateWebhookApiKeyResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey                  string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	PreviousApiKeyExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=previous_api_key_expires_at,json=previousApiKeyExpiresAt,proto3" json:"previous_api_key_expires_at,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RotateWebhookApiKeyResponse) Reset() {
+	*x = RotateWebhookApiKeyResponse{}
+	mi := &file_hookify_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateWebhookApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateWebhookApiKeyResponse) ProtoMessage() {}
+
+func (x *RotateWebhookApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateWebhookApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateWebhookApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RotateWebhookApiKeyResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *RotateWebhookApiKeyResponse) GetPreviousApiKeyExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousApiKeyExpiresAt
+	}
+	return nil
+}
+
+type SubmitEventRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId      int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Payload        string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	ApiKey         string                 `protobuf:"bytes,3,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	EventType      string                 `protobuf:"bytes,5,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubmitEventRequest) Reset() {
+	*x = SubmitEventRequest{}
+	mi := &file_hookify_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitEventRequest) ProtoMessage() {}
+
+func (x *SubmitEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitEventRequest.ProtoReflect.Descriptor instead.
+func (*SubmitEventRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubmitEventRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *SubmitEventRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *SubmitEventRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *SubmitEventRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SubmitEventRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+type SubmitEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitEventResponse) Reset() {
+	*x = SubmitEventResponse{}
+	mi := &file_hookify_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitEventResponse) ProtoMessage() {}
+
+func (x *SubmitEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitEventResponse.ProtoReflect.Descriptor instead.
+func (*SubmitEventResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SubmitEventResponse) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SubmitEventResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+type SubmitEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SubmitEventRequest  `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitEventsRequest) Reset() {
+	*x = SubmitEventsRequest{}
+	mi := &file_hookify_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitEventsRequest) ProtoMessage() {}
+
+func (x *SubmitEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubmitEventsRequest) GetEvents() []*SubmitEventRequest {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type SubmitEventResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	ErrorCode     int32                  `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitEventResult) Reset() {
+	*x = SubmitEventResult{}
+	mi := &file_hookify_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitEventResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitEventResult) ProtoMessage() {}
+
+func (x *SubmitEventResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitEventResult.ProtoReflect.Descriptor instead.
+func (*SubmitEventResult) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SubmitEventResult) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SubmitEventResult) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *SubmitEventResult) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *SubmitEventResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type SubmitEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SubmitEventResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitEventsResponse) Reset() {
+	*x = SubmitEventsResponse{}
+	mi := &file_hookify_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitEventsResponse) ProtoMessage() {}
+
+func (x *SubmitEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitEventsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitEventsResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SubmitEventsResponse) GetResults() []*SubmitEventResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status        EventStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=hookify.EventStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Sequence      int64                  `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	EventType     string                 `protobuf:"bytes,7,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	MessageId     int64                  `protobuf:"varint,8,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_hookify_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *Event) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Event) GetStatus() EventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EventStatus_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Event) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Event) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Event) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type GetEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
+	mi := &file_hookify_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRequest) ProtoMessage() {}
+
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetEventRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type GetEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventResponse) Reset() {
+	*x = GetEventResponse{}
+	mi := &file_hookify_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventResponse) ProtoMessage() {}
+
+func (x *GetEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventResponse.ProtoReflect.Descriptor instead.
+func (*GetEventResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetEventResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type ListEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Status        EventStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=hookify.EventStatus" json:"status,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	MessageId     int64                  `protobuf:"varint,7,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_hookify_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListEventsRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetStatus() EventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EventStatus_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *ListEventsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type ListEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_hookify_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsResponse) ProtoMessage() {}
+
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListEventsResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeliveryAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId       int64                  `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,3,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Attempt       int32                  `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ResponseBody  string                 `protobuf:"bytes,7,opt,name=response_body,json=responseBody,proto3" json:"response_body,omitempty"`
+	Latency       *durationpb.Duration   `protobuf:"bytes,8,opt,name=latency,proto3" json:"latency,omitempty"`
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryAttempt) Reset() {
+	*x = DeliveryAttempt{}
+	mi := &file_hookify_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttempt) ProtoMessage() {}
+
+func (x *DeliveryAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAttempt.ProtoReflect.Descriptor instead.
+func (*DeliveryAttempt) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeliveryAttempt) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *DeliveryAttempt) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetResponseBody() string {
+	if x != nil {
+		return x.ResponseBody
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *DeliveryAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListDeliveryAttemptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeliveryAttemptsRequest) Reset() {
+	*x = ListDeliveryAttemptsRequest{}
+	mi := &file_hookify_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeliveryAttemptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveryAttemptsRequest) ProtoMessage() {}
+
+func (x *ListDeliveryAttemptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveryAttemptsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeliveryAttemptsRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListDeliveryAttemptsRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *ListDeliveryAttemptsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeliveryAttemptsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeliveryAttemptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempts      []*DeliveryAttempt     `protobuf:"bytes,1,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeliveryAttemptsResponse) Reset() {
+	*x = ListDeliveryAttemptsResponse{}
+	mi := &file_hookify_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeliveryAttemptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveryAttemptsResponse) ProtoMessage() {}
+
+func (x *ListDeliveryAttemptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveryAttemptsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeliveryAttemptsResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListDeliveryAttemptsResponse) GetAttempts() []*DeliveryAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *ListDeliveryAttemptsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RedeliverEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverEventRequest) Reset() {
+	*x = RedeliverEventRequest{}
+	mi := &file_hookify_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverEventRequest) ProtoMessage() {}
+
+func (x *RedeliverEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverEventRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverEventRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RedeliverEventRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type RedeliverEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverEventResponse) Reset() {
+	*x = RedeliverEventResponse{}
+	mi := &file_hookify_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverEventResponse) ProtoMessage() {}
+
+func (x *RedeliverEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverEventResponse.ProtoReflect.Descriptor instead.
+func (*RedeliverEventResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{35}
+}
+
+type ReplayEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Status        EventStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=hookify.EventStatus" json:"status,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	RatePerSecond float64                `protobuf:"fixed64,5,opt,name=rate_per_second,json=ratePerSecond,proto3" json:"rate_per_second,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayEventsRequest) Reset() {
+	*x = ReplayEventsRequest{}
+	mi := &file_hookify_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayEventsRequest) ProtoMessage() {}
+
+func (x *ReplayEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayEventsRequest.ProtoReflect.Descriptor instead.
+func (*ReplayEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReplayEventsRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ReplayEventsRequest) GetStatus() EventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EventStatus_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *ReplayEventsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ReplayEventsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ReplayEventsRequest) GetRatePerSecond() float64 {
+	if x != nil {
+		return x.RatePerSecond
+	}
+	return 0
+}
+
+type ReplayEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scheduled     int64                  `protobuf:"varint,1,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayEventsResponse) Reset() {
+	*x = ReplayEventsResponse{}
+	mi := &file_hookify_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayEventsResponse) ProtoMessage() {}
+
+func (x *ReplayEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayEventsResponse.ProtoReflect.Descriptor instead.
+func (*ReplayEventsResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReplayEventsResponse) GetScheduled() int64 {
+	if x != nil {
+		return x.Scheduled
+	}
+	return 0
+}
+
+type DeadLetter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source        DeadLetterSource       `protobuf:"varint,2,opt,name=source,proto3,enum=hookify.DeadLetterSource" json:"source,omitempty"`
+	EventId       int64                  `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,4,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	LastError     string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Attempts      int32                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_hookify_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeadLetter) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetSource() DeadLetterSource {
+	if x != nil {
+		return x.Source
+	}
+	return DeadLetterSource_DEAD_LETTER_SOURCE_UNSPECIFIED
+}
+
+func (x *DeadLetter) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Source        DeadLetterSource       `protobuf:"varint,2,opt,name=source,proto3,enum=hookify.DeadLetterSource" json:"source,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_hookify_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListDeadLettersRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetSource() DeadLetterSource {
+	if x != nil {
+		return x.Source
+	}
+	return DeadLetterSource_DEAD_LETTER_SOURCE_UNSPECIFIED
+}
+
+func (x *ListDeadLettersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_hookify_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RequeueDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterId  int64                  `protobuf:"varint,1,opt,name=dead_letter_id,json=deadLetterId,proto3" json:"dead_letter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueDeadLetterRequest) Reset() {
+	*x = RequeueDeadLetterRequest{}
+	mi := &file_hookify_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueDeadLetterRequest) ProtoMessage() {}
+
+func (x *RequeueDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*RequeueDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RequeueDeadLetterRequest) GetDeadLetterId() int64 {
+	if x != nil {
+		return x.DeadLetterId
+	}
+	return 0
+}
+
+type RequeueDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueDeadLetterResponse) Reset() {
+	*x = RequeueDeadLetterResponse{}
+	mi := &file_hookify_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueDeadLetterResponse) ProtoMessage() {}
+
+func (x *RequeueDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*RequeueDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RequeueDeadLetterResponse) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type PurgeDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Source        DeadLetterSource       `protobuf:"varint,2,opt,name=source,proto3,enum=hookify.DeadLetterSource" json:"source,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	All           bool                   `protobuf:"varint,4,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersRequest) Reset() {
+	*x = PurgeDeadLettersRequest{}
+	mi := &file_hookify_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersRequest) ProtoMessage() {}
+
+func (x *PurgeDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PurgeDeadLettersRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *PurgeDeadLettersRequest) GetSource() DeadLetterSource {
+	if x != nil {
+		return x.Source
+	}
+	return DeadLetterSource_DEAD_LETTER_SOURCE_UNSPECIFIED
+}
+
+func (x *PurgeDeadLettersRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *PurgeDeadLettersRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type PurgeDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int64                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersResponse) Reset() {
+	*x = PurgeDeadLettersResponse{}
+	mi := &file_hookify_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersResponse) ProtoMessage() {}
+
+func (x *PurgeDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PurgeDeadLettersResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+type Application struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Application) Reset() {
+	*x = Application{}
+	mi := &file_hookify_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Application) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Application) ProtoMessage() {}
+
+func (x *Application) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Application.ProtoReflect.Descriptor instead.
+func (*Application) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Application) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Application) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Application) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateApplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApplicationRequest) Reset() {
+	*x = CreateApplicationRequest{}
+	mi := &file_hookify_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApplicationRequest) ProtoMessage() {}
+
+func (x *CreateApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApplicationRequest.ProtoReflect.Descriptor instead.
+func (*CreateApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateApplicationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateApplicationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId int64                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApplicationResponse) Reset() {
+	*x = CreateApplicationResponse{}
+	mi := &file_hookify_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApplicationResponse) ProtoMessage() {}
+
+func (x *CreateApplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApplicationResponse.ProtoReflect.Descriptor instead.
+func (*CreateApplicationResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateApplicationResponse) GetApplicationId() int64 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *CreateApplicationResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+type GetApplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId int64                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApplicationRequest) Reset() {
+	*x = GetApplicationRequest{}
+	mi := &file_hookify_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApplicationRequest) ProtoMessage() {}
+
+func (x *GetApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApplicationRequest.ProtoReflect.Descriptor instead.
+func (*GetApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetApplicationRequest) GetApplicationId() int64 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+type GetApplicationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Application   *Application           `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateWebhookRequest) Reset() {
-	*x = CreateWebhookRequest{}
-	mi := &file_hookify_proto_msgTypes[0]
+func (x *GetApplicationResponse) Reset() {
+	*x = GetApplicationResponse{}
+	mi := &file_hookify_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateWebhookRequest) String() string {
+func (x *GetApplicationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateWebhookRequest) ProtoMessage() {}
+func (*GetApplicationResponse) ProtoMessage() {}
 
-func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hookify_proto_msgTypes[0]
+func (x *GetApplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -53,41 +3088,40 @@ func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
-func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
-	return file_hookify_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use GetApplicationResponse.ProtoReflect.Descriptor instead.
+func (*GetApplicationResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *CreateWebhookRequest) GetUrl() string {
+func (x *GetApplicationResponse) GetApplication() *Application {
 	if x != nil {
-		return x.Url
+		return x.Application
 	}
-	return ""
+	return nil
 }
 
-type CreateWebhookResponse struct {
+type DeleteApplicationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
-	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	ApplicationId int64                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateWebhookResponse) Reset() {
-	*x = CreateWebhookResponse{}
-	mi := &file_hookify_proto_msgTypes[1]
+func (x *DeleteApplicationRequest) Reset() {
+	*x = DeleteApplicationRequest{}
+	mi := &file_hookify_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateWebhookResponse) String() string {
+func (x *DeleteApplicationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateWebhookResponse) ProtoMessage() {}
+func (*DeleteApplicationRequest) ProtoMessage() {}
 
-func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookify_proto_msgTypes[1]
+func (x *DeleteApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -98,49 +3132,80 @@ func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
-func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
-	return file_hookify_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use DeleteApplicationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *CreateWebhookResponse) GetWebhookId() int64 {
+func (x *DeleteApplicationRequest) GetApplicationId() int64 {
 	if x != nil {
-		return x.WebhookId
+		return x.ApplicationId
 	}
 	return 0
 }
 
-func (x *CreateWebhookResponse) GetSecret() string {
+type DeleteApplicationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApplicationResponse) Reset() {
+	*x = DeleteApplicationResponse{}
+	mi := &file_hookify_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApplicationResponse) ProtoMessage() {}
+
+func (x *DeleteApplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[51]
 	if x != nil {
-		return x.Secret
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type SubmitEventRequest struct {
+// Deprecated: Use DeleteApplicationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteApplicationResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{51}
+}
+
+type Message struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
-	Payload       string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	Secret        string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ApplicationId int64                  `protobuf:"varint,2,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Payload       string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitEventRequest) Reset() {
-	*x = SubmitEventRequest{}
-	mi := &file_hookify_proto_msgTypes[2]
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_hookify_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitEventRequest) String() string {
+func (x *Message) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitEventRequest) ProtoMessage() {}
+func (*Message) ProtoMessage() {}
 
-func (x *SubmitEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hookify_proto_msgTypes[2]
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,55 +3216,137 @@ func (x *SubmitEventRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitEventRequest.ProtoReflect.Descriptor instead.
-func (*SubmitEventRequest) Descriptor() ([]byte, []int) {
-	return file_hookify_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *SubmitEventRequest) GetWebhookId() int64 {
+func (x *Message) GetId() int64 {
 	if x != nil {
-		return x.WebhookId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *SubmitEventRequest) GetPayload() string {
+func (x *Message) GetApplicationId() int64 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *Message) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Message) GetPayload() string {
 	if x != nil {
 		return x.Payload
 	}
 	return ""
 }
 
-func (x *SubmitEventRequest) GetSecret() string {
+func (x *Message) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Secret
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PublishMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationId int64                  `protobuf:"varint,1,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Payload       string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishMessageRequest) Reset() {
+	*x = PublishMessageRequest{}
+	mi := &file_hookify_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishMessageRequest) ProtoMessage() {}
+
+func (x *PublishMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishMessageRequest.ProtoReflect.Descriptor instead.
+func (*PublishMessageRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *PublishMessageRequest) GetApplicationId() int64 {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return 0
+}
+
+func (x *PublishMessageRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
 	}
 	return ""
 }
 
-type SubmitEventResponse struct {
+func (x *PublishMessageRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *PublishMessageRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+type PublishMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Deliveries    []*Event               `protobuf:"bytes,2,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitEventResponse) Reset() {
-	*x = SubmitEventResponse{}
-	mi := &file_hookify_proto_msgTypes[3]
+func (x *PublishMessageResponse) Reset() {
+	*x = PublishMessageResponse{}
+	mi := &file_hookify_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitEventResponse) String() string {
+func (x *PublishMessageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitEventResponse) ProtoMessage() {}
+func (*PublishMessageResponse) ProtoMessage() {}
 
-func (x *SubmitEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookify_proto_msgTypes[3]
+func (x *PublishMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,47 +3357,419 @@ func (x *SubmitEventResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitEventResponse.ProtoReflect.Descriptor instead.
-func (*SubmitEventResponse) Descriptor() ([]byte, []int) {
-	return file_hookify_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use PublishMessageResponse.ProtoReflect.Descriptor instead.
+func (*PublishMessageResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *SubmitEventResponse) GetEventId() int64 {
+func (x *PublishMessageResponse) GetMessageId() int64 {
 	if x != nil {
-		return x.EventId
+		return x.MessageId
 	}
 	return 0
 }
 
-func (x *SubmitEventResponse) GetCreated() bool {
+func (x *PublishMessageResponse) GetDeliveries() []*Event {
 	if x != nil {
-		return x.Created
+		return x.Deliveries
 	}
-	return false
+	return nil
+}
+
+type GetMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageRequest) Reset() {
+	*x = GetMessageRequest{}
+	mi := &file_hookify_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageRequest) ProtoMessage() {}
+
+func (x *GetMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageRequest) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type GetMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageResponse) Reset() {
+	*x = GetMessageResponse{}
+	mi := &file_hookify_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageResponse) ProtoMessage() {}
+
+func (x *GetMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookify_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageResponse) Descriptor() ([]byte, []int) {
+	return file_hookify_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
 }
 
 var File_hookify_proto protoreflect.FileDescriptor
 
 const file_hookify_proto_rawDesc = "" +
 	"\n" +
-	"\rhookify.proto\x12\ahookify\"(\n" +
+	"\rhookify.proto\x12\ahookify\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa0\x02\n" +
+	"\vRetryPolicy\x12D\n" +
+	"\x10initial_interval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x0finitialInterval\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x02 \x01(\x01R\n" +
+	"multiplier\x12<\n" +
+	"\fmax_interval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vmaxInterval\x12\x16\n" +
+	"\x06jitter\x18\x04 \x01(\x01R\x06jitter\x12!\n" +
+	"\fmax_attempts\x18\x05 \x01(\x05R\vmaxAttempts\x122\n" +
+	"\amax_age\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x06maxAge\"\xd2\x04\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12W\n" +
+	"\x1aprevious_secret_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x17previousSecretExpiresAt\x12C\n" +
+	"\x10signature_scheme\x18\x05 \x01(\x0e2\x18.hookify.SignatureSchemeR\x0fsignatureScheme\x12X\n" +
+	"\x1bprevious_api_key_expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x17previousApiKeyExpiresAt\x127\n" +
+	"\fretry_policy\x18\a \x01(\v2\x14.hookify.RetryPolicyR\vretryPolicy\x12.\n" +
+	"\x06status\x18\b \x01(\x0e2\x16.hookify.WebhookStatusR\x06status\x12'\n" +
+	"\x0fdisabled_reason\x18\t \x01(\tR\x0edisabledReason\x12\x18\n" +
+	"\aordered\x18\n" +
+	" \x01(\bR\aordered\x12\x1f\n" +
+	"\vevent_types\x18\v \x03(\tR\n" +
+	"eventTypes\x12%\n" +
+	"\x0eapplication_id\x18\f \x01(\x03R\rapplicationId\"O\n" +
 	"\x14CreateWebhookRequest\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\"N\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12%\n" +
+	"\x0eapplication_id\x18\x02 \x01(\x03R\rapplicationId\"g\n" +
 	"\x15CreateWebhookResponse\x12\x1d\n" +
 	"\n" +
 	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12\x16\n" +
-	"\x06secret\x18\x02 \x01(\tR\x06secret\"e\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x17\n" +
+	"\aapi_key\x18\x03 \x01(\tR\x06apiKey\"2\n" +
+	"\x11GetWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\"@\n" +
+	"\x12GetWebhookResponse\x12*\n" +
+	"\awebhook\x18\x01 \x01(\v2\x10.hookify.WebhookR\awebhook\"Q\n" +
+	"\x13ListWebhooksRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"l\n" +
+	"\x14ListWebhooksResponse\x12,\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x10.hookify.WebhookR\bwebhooks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe1\x02\n" +
+	"\x14UpdateWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12\x15\n" +
+	"\x03url\x18\x02 \x01(\tH\x00R\x03url\x88\x01\x01\x12C\n" +
+	"\x10signature_scheme\x18\x03 \x01(\x0e2\x18.hookify.SignatureSchemeR\x0fsignatureScheme\x127\n" +
+	"\fretry_policy\x18\x04 \x01(\v2\x14.hookify.RetryPolicyR\vretryPolicy\x12,\n" +
+	"\x12clear_retry_policy\x18\x05 \x01(\bR\x10clearRetryPolicy\x12\x1d\n" +
+	"\aordered\x18\x06 \x01(\bH\x01R\aordered\x88\x01\x01\x124\n" +
+	"\vevent_types\x18\a \x01(\v2\x13.hookify.EventTypesR\n" +
+	"eventTypesB\x06\n" +
+	"\x04_urlB\n" +
+	"\n" +
+	"\b_ordered\"(\n" +
+	"\n" +
+	"EventTypes\x12\x1a\n" +
+	"\bpatterns\x18\x01 \x03(\tR\bpatterns\"C\n" +
+	"\x15UpdateWebhookResponse\x12*\n" +
+	"\awebhook\x18\x01 \x01(\v2\x10.hookify.WebhookR\awebhook\"5\n" +
+	"\x14DeleteWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\"\x17\n" +
+	"\x15DeleteWebhookResponse\"4\n" +
+	"\x13PauseWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\"B\n" +
+	"\x14PauseWebhookResponse\x12*\n" +
+	"\awebhook\x18\x01 \x01(\v2\x10.hookify.WebhookR\awebhook\"5\n" +
+	"\x14ResumeWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\"C\n" +
+	"\x15ResumeWebhookResponse\x12*\n" +
+	"\awebhook\x18\x01 \x01(\v2\x10.hookify.WebhookR\awebhook\"y\n" +
+	"\x1aRotateWebhookSecretRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12<\n" +
+	"\fgrace_period\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\vgracePeriod\"\x8e\x01\n" +
+	"\x1bRotateWebhookSecretResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12W\n" +
+	"\x1aprevious_secret_expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x17previousSecretExpiresAt\"y\n" +
+	"\x1aRotateWebhookApiKeyRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12<\n" +
+	"\fgrace_period\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\vgracePeriod\"\x90\x01\n" +
+	"\x1bRotateWebhookApiKeyResponse\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12X\n" +
+	"\x1bprevious_api_key_expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x17previousApiKeyExpiresAt\"\xae\x01\n" +
 	"\x12SubmitEventRequest\x12\x1d\n" +
 	"\n" +
 	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\tR\apayload\x12\x16\n" +
-	"\x06secret\x18\x03 \x01(\tR\x06secret\"J\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\x12\x17\n" +
+	"\aapi_key\x18\x03 \x01(\tR\x06apiKey\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x05 \x01(\tR\teventType\"J\n" +
 	"\x13SubmitEventResponse\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x18\n" +
-	"\acreated\x18\x02 \x01(\bR\acreated2\xa3\x01\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"J\n" +
+	"\x13SubmitEventsRequest\x123\n" +
+	"\x06events\x18\x01 \x03(\v2\x1b.hookify.SubmitEventRequestR\x06events\"\x8c\x01\n" +
+	"\x11SubmitEventResult\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x05R\terrorCode\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"L\n" +
+	"\x14SubmitEventsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.hookify.SubmitEventResultR\aresults\"\x93\x02\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x03R\twebhookId\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12,\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x14.hookify.EventStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x03R\bsequence\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\a \x01(\tR\teventType\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\b \x01(\x03R\tmessageId\",\n" +
+	"\x0fGetEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"8\n" +
+	"\x10GetEventResponse\x12$\n" +
+	"\x05event\x18\x01 \x01(\v2\x0e.hookify.EventR\x05event\"\xbf\x02\n" +
+	"\x11ListEventsRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12,\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x14.hookify.EventStatusR\x06status\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\a \x01(\x03R\tmessageId\"d\n" +
+	"\x12ListEventsResponse\x12&\n" +
+	"\x06events\x18\x01 \x03(\v2\x0e.hookify.EventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc5\x02\n" +
+	"\x0fDeliveryAttempt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\x03R\aeventId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x03 \x01(\x03R\twebhookId\x12\x18\n" +
+	"\aattempt\x18\x04 \x01(\x05R\aattempt\x12=\n" +
+	"\frequested_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12\x1f\n" +
+	"\vstatus_code\x18\x06 \x01(\x05R\n" +
+	"statusCode\x12#\n" +
+	"\rresponse_body\x18\a \x01(\tR\fresponseBody\x123\n" +
+	"\alatency\x18\b \x01(\v2\x19.google.protobuf.DurationR\alatency\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"t\n" +
+	"\x1bListDeliveryAttemptsRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"|\n" +
+	"\x1cListDeliveryAttemptsResponse\x124\n" +
+	"\battempts\x18\x01 \x03(\v2\x18.hookify.DeliveryAttemptR\battempts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"2\n" +
+	"\x15RedeliverEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"\x18\n" +
+	"\x16RedeliverEventResponse\"\x8e\x02\n" +
+	"\x13ReplayEventsRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12,\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x14.hookify.EventStatusR\x06status\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12&\n" +
+	"\x0frate_per_second\x18\x05 \x01(\x01R\rratePerSecond\"4\n" +
+	"\x14ReplayEventsResponse\x12\x1c\n" +
+	"\tscheduled\x18\x01 \x01(\x03R\tscheduled\"\xb1\x02\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x121\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x19.hookify.DeadLetterSourceR\x06source\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\x03R\aeventId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x04 \x01(\x03R\twebhookId\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x12\x1a\n" +
+	"\battempts\x18\b \x01(\x05R\battempts\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa6\x01\n" +
+	"\x16ListDeadLettersRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x121\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x19.hookify.DeadLetterSourceR\x06source\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"y\n" +
+	"\x17ListDeadLettersResponse\x126\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x13.hookify.DeadLetterR\vdeadLetters\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"@\n" +
+	"\x18RequeueDeadLetterRequest\x12$\n" +
+	"\x0edead_letter_id\x18\x01 \x01(\x03R\fdeadLetterId\"6\n" +
+	"\x19RequeueDeadLetterResponse\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"\xc0\x01\n" +
+	"\x17PurgeDeadLettersRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x121\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x19.hookify.DeadLetterSourceR\x06source\x12A\n" +
+	"\x0ecreated_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x10\n" +
+	"\x03all\x18\x04 \x01(\bR\x03all\"2\n" +
+	"\x18PurgeDeadLettersResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged\"l\n" +
+	"\vApplication\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\".\n" +
+	"\x18CreateApplicationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"[\n" +
+	"\x19CreateApplicationResponse\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x03R\rapplicationId\x12\x17\n" +
+	"\aapi_key\x18\x02 \x01(\tR\x06apiKey\">\n" +
+	"\x15GetApplicationRequest\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x03R\rapplicationId\"P\n" +
+	"\x16GetApplicationResponse\x126\n" +
+	"\vapplication\x18\x01 \x01(\v2\x14.hookify.ApplicationR\vapplication\"A\n" +
+	"\x18DeleteApplicationRequest\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x03R\rapplicationId\"\x1b\n" +
+	"\x19DeleteApplicationResponse\"\xb4\x01\n" +
+	"\aMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12%\n" +
+	"\x0eapplication_id\x18\x02 \x01(\x03R\rapplicationId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x90\x01\n" +
+	"\x15PublishMessageRequest\x12%\n" +
+	"\x0eapplication_id\x18\x01 \x01(\x03R\rapplicationId\x12\x17\n" +
+	"\aapi_key\x18\x02 \x01(\tR\x06apiKey\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\"g\n" +
+	"\x16PublishMessageResponse\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12.\n" +
+	"\n" +
+	"deliveries\x18\x02 \x03(\v2\x0e.hookify.EventR\n" +
+	"deliveries\"2\n" +
+	"\x11GetMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"@\n" +
+	"\x12GetMessageResponse\x12*\n" +
+	"\amessage\x18\x01 \x01(\v2\x10.hookify.MessageR\amessage*o\n" +
+	"\x0fSignatureScheme\x12 \n" +
+	"\x1cSIGNATURE_SCHEME_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19SIGNATURE_SCHEME_STANDARD\x10\x01\x12\x1b\n" +
+	"\x17SIGNATURE_SCHEME_LEGACY\x10\x02*\x82\x01\n" +
+	"\rWebhookStatus\x12\x1e\n" +
+	"\x1aWEBHOOK_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15WEBHOOK_STATUS_ACTIVE\x10\x01\x12\x1b\n" +
+	"\x17WEBHOOK_STATUS_DISABLED\x10\x02\x12\x19\n" +
+	"\x15WEBHOOK_STATUS_PAUSED\x10\x03*\x95\x01\n" +
+	"\vEventStatus\x12\x1c\n" +
+	"\x18EVENT_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14EVENT_STATUS_PENDING\x10\x01\x12\x1a\n" +
+	"\x16EVENT_STATUS_DELIVERED\x10\x02\x12\x17\n" +
+	"\x13EVENT_STATUS_FAILED\x10\x03\x12\x19\n" +
+	"\x15EVENT_STATUS_FILTERED\x10\x04*v\n" +
+	"\x10DeadLetterSource\x12\"\n" +
+	"\x1eDEAD_LETTER_SOURCE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19DEAD_LETTER_SOURCE_OUTBOX\x10\x01\x12\x1f\n" +
+	"\x1bDEAD_LETTER_SOURCE_CONSUMER\x10\x022\x95\x10\n" +
 	"\aHookify\x12N\n" +
-	"\rCreateWebhook\x12\x1d.hookify.CreateWebhookRequest\x1a\x1e.hookify.CreateWebhookResponse\x12H\n" +
-	"\vSubmitEvent\x12\x1b.hookify.SubmitEventRequest\x1a\x1c.hookify.SubmitEventResponseB\x15Z\x13hookify/gen/hookifyb\x06proto3"
+	"\rCreateWebhook\x12\x1d.hookify.CreateWebhookRequest\x1a\x1e.hookify.CreateWebhookResponse\x12E\n" +
+	"\n" +
+	"GetWebhook\x12\x1a.hookify.GetWebhookRequest\x1a\x1b.hookify.GetWebhookResponse\x12K\n" +
+	"\fListWebhooks\x12\x1c.hookify.ListWebhooksRequest\x1a\x1d.hookify.ListWebhooksResponse\x12N\n" +
+	"\rUpdateWebhook\x12\x1d.hookify.UpdateWebhookRequest\x1a\x1e.hookify.UpdateWebhookResponse\x12N\n" +
+	"\rDeleteWebhook\x12\x1d.hookify.DeleteWebhookRequest\x1a\x1e.hookify.DeleteWebhookResponse\x12K\n" +
+	"\fPauseWebhook\x12\x1c.hookify.PauseWebhookRequest\x1a\x1d.hookify.PauseWebhookResponse\x12N\n" +
+	"\rResumeWebhook\x12\x1d.hookify.ResumeWebhookRequest\x1a\x1e.hookify.ResumeWebhookResponse\x12`\n" +
+	"\x13RotateWebhookSecret\x12#.hookify.RotateWebhookSecretRequest\x1a$.hookify.RotateWebhookSecretResponse\x12`\n" +
+	"\x13RotateWebhookApiKey\x12#.hookify.RotateWebhookApiKeyRequest\x1a$.hookify.RotateWebhookApiKeyResponse\x12H\n" +
+	"\vSubmitEvent\x12\x1b.hookify.SubmitEventRequest\x1a\x1c.hookify.SubmitEventResponse\x12K\n" +
+	"\fSubmitEvents\x12\x1c.hookify.SubmitEventsRequest\x1a\x1d.hookify.SubmitEventsResponse\x12Q\n" +
+	"\x11SubmitEventStream\x12\x1b.hookify.SubmitEventRequest\x1a\x1d.hookify.SubmitEventsResponse(\x01\x12?\n" +
+	"\bGetEvent\x12\x18.hookify.GetEventRequest\x1a\x19.hookify.GetEventResponse\x12E\n" +
+	"\n" +
+	"ListEvents\x12\x1a.hookify.ListEventsRequest\x1a\x1b.hookify.ListEventsResponse\x12c\n" +
+	"\x14ListDeliveryAttempts\x12$.hookify.ListDeliveryAttemptsRequest\x1a%.hookify.ListDeliveryAttemptsResponse\x12Q\n" +
+	"\x0eRedeliverEvent\x12\x1e.hookify.RedeliverEventRequest\x1a\x1f.hookify.RedeliverEventResponse\x12K\n" +
+	"\fReplayEvents\x12\x1c.hookify.ReplayEventsRequest\x1a\x1d.hookify.ReplayEventsResponse\x12T\n" +
+	"\x0fListDeadLetters\x12\x1f.hookify.ListDeadLettersRequest\x1a .hookify.ListDeadLettersResponse\x12Z\n" +
+	"\x11RequeueDeadLetter\x12!.hookify.RequeueDeadLetterRequest\x1a\".hookify.RequeueDeadLetterResponse\x12W\n" +
+	"\x10PurgeDeadLetters\x12 .hookify.PurgeDeadLettersRequest\x1a!.hookify.PurgeDeadLettersResponse\x12Z\n" +
+	"\x11CreateApplication\x12!.hookify.CreateApplicationRequest\x1a\".hookify.CreateApplicationResponse\x12Q\n" +
+	"\x0eGetApplication\x12\x1e.hookify.GetApplicationRequest\x1a\x1f.hookify.GetApplicationResponse\x12Z\n" +
+	"\x11DeleteApplication\x12!.hookify.DeleteApplicationRequest\x1a\".hookify.DeleteApplicationResponse\x12Q\n" +
+	"\x0ePublishMessage\x12\x1e.hookify.PublishMessageRequest\x1a\x1f.hookify.PublishMessageResponse\x12E\n" +
+	"\n" +
+	"GetMessage\x12\x1a.hookify.GetMessageRequest\x1a\x1b.hookify.GetMessageResponseB\x15Z\x13hookify/gen/hookifyb\x06proto3"
 
 var (
 	file_hookify_proto_rawDescOnce sync.Once
@@ -264,23 +3783,176 @@ func file_hookify_proto_rawDescGZIP() []byte {
 	return file_hookify_proto_rawDescData
 }
 
-var file_hookify_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_hookify_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_hookify_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_hookify_proto_goTypes = []any{
-	(*CreateWebhookRequest)(nil),  // 0: hookify.CreateWebhookRequest
-	(*CreateWebhookResponse)(nil), // 1: hookify.CreateWebhookResponse
-	(*SubmitEventRequest)(nil),    // 2: hookify.SubmitEventRequest
-	(*SubmitEventResponse)(nil),   // 3: hookify.SubmitEventResponse
+	(SignatureScheme)(0),                 // 0: hookify.SignatureScheme
+	(WebhookStatus)(0),                   // 1: hookify.WebhookStatus
+	(EventStatus)(0),                     // 2: hookify.EventStatus
+	(DeadLetterSource)(0),                // 3: hookify.DeadLetterSource
+	(*RetryPolicy)(nil),                  // 4: hookify.RetryPolicy
+	(*Webhook)(nil),                      // 5: hookify.Webhook
+	(*CreateWebhookRequest)(nil),         // 6: hookify.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),        // 7: hookify.CreateWebhookResponse
+	(*GetWebhookRequest)(nil),            // 8: hookify.GetWebhookRequest
+	(*GetWebhookResponse)(nil),           // 9: hookify.GetWebhookResponse
+	(*ListWebhooksRequest)(nil),          // 10: hookify.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),         // 11: hookify.ListWebhooksResponse
+	(*UpdateWebhookRequest)(nil),         // 12: hookify.UpdateWebhookRequest
+	(*EventTypes)(nil),                   // 13: hookify.EventTypes
+	(*UpdateWebhookResponse)(nil),        // 14: hookify.UpdateWebhookResponse
+	(*DeleteWebhookRequest)(nil),         // 15: hookify.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),        // 16: hookify.DeleteWebhookResponse
+	(*PauseWebhookRequest)(nil),          // 17: hookify.PauseWebhookRequest
+	(*PauseWebhookResponse)(nil),         // 18: hookify.PauseWebhookResponse
+	(*ResumeWebhookRequest)(nil),         // 19: hookify.ResumeWebhookRequest
+	(*ResumeWebhookResponse)(nil),        // 20: hookify.ResumeWebhookResponse
+	(*RotateWebhookSecretRequest)(nil),   // 21: hookify.RotateWebhookSecretRequest
+	(*RotateWebhookSecretResponse)(nil),  // 22: hookify.RotateWebhookSecretResponse
+	(*RotateWebhookApiKeyRequest)(nil),   // 23: hookify.RotateWebhookApiKeyRequest
+	(*RotateWebhookApiKeyResponse)(nil),  // 24: hookify.RotateWebhookApiKeyResponse
+	(*SubmitEventRequest)(nil),           // 25: hookify.SubmitEventRequest
+	(*SubmitEventResponse)(nil),          // 26: hookify.SubmitEventResponse
+	(*SubmitEventsRequest)(nil),          // 27: hookify.SubmitEventsRequest
+	(*SubmitEventResult)(nil),            // 28: hookify.SubmitEventResult
+	(*SubmitEventsResponse)(nil),         // 29: hookify.SubmitEventsResponse
+	(*Event)(nil),                        // 30: hookify.Event
+	(*GetEventRequest)(nil),              // 31: hookify.GetEventRequest
+	(*GetEventResponse)(nil),             // 32: hookify.GetEventResponse
+	(*ListEventsRequest)(nil),            // 33: hookify.ListEventsRequest
+	(*ListEventsResponse)(nil),           // 34: hookify.ListEventsResponse
+	(*DeliveryAttempt)(nil),              // 35: hookify.DeliveryAttempt
+	(*ListDeliveryAttemptsRequest)(nil),  // 36: hookify.ListDeliveryAttemptsRequest
+	(*ListDeliveryAttemptsResponse)(nil), // 37: hookify.ListDeliveryAttemptsResponse
+	(*RedeliverEventRequest)(nil),        // 38: hookify.RedeliverEventRequest
+	(*RedeliverEventResponse)(nil),       // 39: hookify.RedeliverEventResponse
+	(*ReplayEventsRequest)(nil),          // 40: hookify.ReplayEventsRequest
+	(*ReplayEventsResponse)(nil),         // 41: hookify.ReplayEventsResponse
+	(*DeadLetter)(nil),                   // 42: hookify.DeadLetter
+	(*ListDeadLettersRequest)(nil),       // 43: hookify.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),      // 44: hookify.ListDeadLettersResponse
+	(*RequeueDeadLetterRequest)(nil),     // 45: hookify.RequeueDeadLetterRequest
+	(*RequeueDeadLetterResponse)(nil),    // 46: hookify.RequeueDeadLetterResponse
+	(*PurgeDeadLettersRequest)(nil),      // 47: hookify.PurgeDeadLettersRequest
+	(*PurgeDeadLettersResponse)(nil),     // 48: hookify.PurgeDeadLettersResponse
+	(*Application)(nil),                  // 49: hookify.Application
+	(*CreateApplicationRequest)(nil),     // 50: hookify.CreateApplicationRequest
+	(*CreateApplicationResponse)(nil),    // 51: hookify.CreateApplicationResponse
+	(*GetApplicationRequest)(nil),        // 52: hookify.GetApplicationRequest
+	(*GetApplicationResponse)(nil),       // 53: hookify.GetApplicationResponse
+	(*DeleteApplicationRequest)(nil),     // 54: hookify.DeleteApplicationRequest
+	(*DeleteApplicationResponse)(nil),    // 55: hookify.DeleteApplicationResponse
+	(*Message)(nil),                      // 56: hookify.Message
+	(*PublishMessageRequest)(nil),        // 57: hookify.PublishMessageRequest
+	(*PublishMessageResponse)(nil),       // 58: hookify.PublishMessageResponse
+	(*GetMessageRequest)(nil),            // 59: hookify.GetMessageRequest
+	(*GetMessageResponse)(nil),           // 60: hookify.GetMessageResponse
+	(*durationpb.Duration)(nil),          // 61: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),        // 62: google.protobuf.Timestamp
 }
 var file_hookify_proto_depIdxs = []int32{
-	0, // 0: hookify.Hookify.CreateWebhook:input_type -> hookify.CreateWebhookRequest
-	2, // 1: hookify.Hookify.SubmitEvent:input_type -> hookify.SubmitEventRequest
-	1, // 2: hookify.Hookify.CreateWebhook:output_type -> hookify.CreateWebhookResponse
-	3, // 3: hookify.Hookify.SubmitEvent:output_type -> hookify.SubmitEventResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	61, // 0: hookify.RetryPolicy.initial_interval:type_name -> google.protobuf.Duration
+	61, // 1: hookify.RetryPolicy.max_interval:type_name -> google.protobuf.Duration
+	61, // 2: hookify.RetryPolicy.max_age:type_name -> google.protobuf.Duration
+	62, // 3: hookify.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	62, // 4: hookify.Webhook.previous_secret_expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: hookify.Webhook.signature_scheme:type_name -> hookify.SignatureScheme
+	62, // 6: hookify.Webhook.previous_api_key_expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 7: hookify.Webhook.retry_policy:type_name -> hookify.RetryPolicy
+	1,  // 8: hookify.Webhook.status:type_name -> hookify.WebhookStatus
+	5,  // 9: hookify.GetWebhookResponse.webhook:type_name -> hookify.Webhook
+	5,  // 10: hookify.ListWebhooksResponse.webhooks:type_name -> hookify.Webhook
+	0,  // 11: hookify.UpdateWebhookRequest.signature_scheme:type_name -> hookify.SignatureScheme
+	4,  // 12: hookify.UpdateWebhookRequest.retry_policy:type_name -> hookify.RetryPolicy
+	13, // 13: hookify.UpdateWebhookRequest.event_types:type_name -> hookify.EventTypes
+	5,  // 14: hookify.UpdateWebhookResponse.webhook:type_name -> hookify.Webhook
+	5,  // 15: hookify.PauseWebhookResponse.webhook:type_name -> hookify.Webhook
+	5,  // 16: hookify.ResumeWebhookResponse.webhook:type_name -> hookify.Webhook
+	61, // 17: hookify.RotateWebhookSecretRequest.grace_period:type_name -> google.protobuf.Duration
+	62, // 18: hookify.RotateWebhookSecretResponse.previous_secret_expires_at:type_name -> google.protobuf.Timestamp
+	61, // 19: hookify.RotateWebhookApiKeyRequest.grace_period:type_name -> google.protobuf.Duration
+	62, // 20: hookify.RotateWebhookApiKeyResponse.previous_api_key_expires_at:type_name -> google.protobuf.Timestamp
+	25, // 21: hookify.SubmitEventsRequest.events:type_name -> hookify.SubmitEventRequest
+	28, // 22: hookify.SubmitEventsResponse.results:type_name -> hookify.SubmitEventResult
+	2,  // 23: hookify.Event.status:type_name -> hookify.EventStatus
+	62, // 24: hookify.Event.created_at:type_name -> google.protobuf.Timestamp
+	30, // 25: hookify.GetEventResponse.event:type_name -> hookify.Event
+	2,  // 26: hookify.ListEventsRequest.status:type_name -> hookify.EventStatus
+	62, // 27: hookify.ListEventsRequest.created_after:type_name -> google.protobuf.Timestamp
+	62, // 28: hookify.ListEventsRequest.created_before:type_name -> google.protobuf.Timestamp
+	30, // 29: hookify.ListEventsResponse.events:type_name -> hookify.Event
+	62, // 30: hookify.DeliveryAttempt.requested_at:type_name -> google.protobuf.Timestamp
+	61, // 31: hookify.DeliveryAttempt.latency:type_name -> google.protobuf.Duration
+	35, // 32: hookify.ListDeliveryAttemptsResponse.attempts:type_name -> hookify.DeliveryAttempt
+	2,  // 33: hookify.ReplayEventsRequest.status:type_name -> hookify.EventStatus
+	62, // 34: hookify.ReplayEventsRequest.created_after:type_name -> google.protobuf.Timestamp
+	62, // 35: hookify.ReplayEventsRequest.created_before:type_name -> google.protobuf.Timestamp
+	3,  // 36: hookify.DeadLetter.source:type_name -> hookify.DeadLetterSource
+	62, // 37: hookify.DeadLetter.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 38: hookify.ListDeadLettersRequest.source:type_name -> hookify.DeadLetterSource
+	42, // 39: hookify.ListDeadLettersResponse.dead_letters:type_name -> hookify.DeadLetter
+	3,  // 40: hookify.PurgeDeadLettersRequest.source:type_name -> hookify.DeadLetterSource
+	62, // 41: hookify.PurgeDeadLettersRequest.created_before:type_name -> google.protobuf.Timestamp
+	62, // 42: hookify.Application.created_at:type_name -> google.protobuf.Timestamp
+	49, // 43: hookify.GetApplicationResponse.application:type_name -> hookify.Application
+	62, // 44: hookify.Message.created_at:type_name -> google.protobuf.Timestamp
+	30, // 45: hookify.PublishMessageResponse.deliveries:type_name -> hookify.Event
+	56, // 46: hookify.GetMessageResponse.message:type_name -> hookify.Message
+	6,  // 47: hookify.Hookify.CreateWebhook:input_type -> hookify.CreateWebhookRequest
+	8,  // 48: hookify.Hookify.GetWebhook:input_type -> hookify.GetWebhookRequest
+	10, // 49: hookify.Hookify.ListWebhooks:input_type -> hookify.ListWebhooksRequest
+	12, // 50: hookify.Hookify.UpdateWebhook:input_type -> hookify.UpdateWebhookRequest
+	15, // 51: hookify.Hookify.DeleteWebhook:input_type -> hookify.DeleteWebhookRequest
+	17, // 52: hookify.Hookify.PauseWebhook:input_type -> hookify.PauseWebhookRequest
+	19, // 53: hookify.Hookify.ResumeWebhook:input_type -> hookify.ResumeWebhookRequest
+	21, // 54: hookify.Hookify.RotateWebhookSecret:input_type -> hookify.RotateWebhookSecretRequest
+	23, // 55: hookify.Hookify.RotateWebhookApiKey:input_type -> hookify.RotateWebhookApiKeyRequest
+	25, // 56: hookify.Hookify.SubmitEvent:input_type -> hookify.SubmitEventRequest
+	27, // 57: hookify.Hookify.SubmitEvents:input_type -> hookify.SubmitEventsRequest
+	25, // 58: hookify.Hookify.SubmitEventStream:input_type -> hookify.SubmitEventRequest
+	31, // 59: hookify.Hookify.GetEvent:input_type -> hookify.GetEventRequest
+	33, // 60: hookify.Hookify.ListEvents:input_type -> hookify.ListEventsRequest
+	36, // 61: hookify.Hookify.ListDeliveryAttempts:input_type -> hookify.ListDeliveryAttemptsRequest
+	38, // 62: hookify.Hookify.RedeliverEvent:input_type -> hookify.RedeliverEventRequest
+	40, // 63: hookify.Hookify.ReplayEvents:input_type -> hookify.ReplayEventsRequest
+	43, // 64: hookify.Hookify.ListDeadLetters:input_type -> hookify.ListDeadLettersRequest
+	45, // 65: hookify.Hookify.RequeueDeadLetter:input_type -> hookify.RequeueDeadLetterRequest
+	47, // 66: hookify.Hookify.PurgeDeadLetters:input_type -> hookify.PurgeDeadLettersRequest
+	50, // 67: hookify.Hookify.CreateApplication:input_type -> hookify.CreateApplicationRequest
+	52, // 68: hookify.Hookify.GetApplication:input_type -> hookify.GetApplicationRequest
+	54, // 69: hookify.Hookify.DeleteApplication:input_type -> hookify.DeleteApplicationRequest
+	57, // 70: hookify.Hookify.PublishMessage:input_type -> hookify.PublishMessageRequest
+	59, // 71: hookify.Hookify.GetMessage:input_type -> hookify.GetMessageRequest
+	7,  // 72: hookify.Hookify.CreateWebhook:output_type -> hookify.CreateWebhookResponse
+	9,  // 73: hookify.Hookify.GetWebhook:output_type -> hookify.GetWebhookResponse
+	11, // 74: hookify.Hookify.ListWebhooks:output_type -> hookify.ListWebhooksResponse
+	14, // 75: hookify.Hookify.UpdateWebhook:output_type -> hookify.UpdateWebhookResponse
+	16, // 76: hookify.Hookify.DeleteWebhook:output_type -> hookify.DeleteWebhookResponse
+	18, // 77: hookify.Hookify.PauseWebhook:output_type -> hookify.PauseWebhookResponse
+	20, // 78: hookify.Hookify.ResumeWebhook:output_type -> hookify.ResumeWebhookResponse
+	22, // 79: hookify.Hookify.RotateWebhookSecret:output_type -> hookify.RotateWebhookSecretResponse
+	24, // 80: hookify.Hookify.RotateWebhookApiKey:output_type -> hookify.RotateWebhookApiKeyResponse
+	26, // 81: hookify.Hookify.SubmitEvent:output_type -> hookify.SubmitEventResponse
+	29, // 82: hookify.Hookify.SubmitEvents:output_type -> hookify.SubmitEventsResponse
+	29, // 83: hookify.Hookify.SubmitEventStream:output_type -> hookify.SubmitEventsResponse
+	32, // 84: hookify.Hookify.GetEvent:output_type -> hookify.GetEventResponse
+	34, // 85: hookify.Hookify.ListEvents:output_type -> hookify.ListEventsResponse
+	37, // 86: hookify.Hookify.ListDeliveryAttempts:output_type -> hookify.ListDeliveryAttemptsResponse
+	39, // 87: hookify.Hookify.RedeliverEvent:output_type -> hookify.RedeliverEventResponse
+	41, // 88: hookify.Hookify.ReplayEvents:output_type -> hookify.ReplayEventsResponse
+	44, // 89: hookify.Hookify.ListDeadLetters:output_type -> hookify.ListDeadLettersResponse
+	46, // 90: hookify.Hookify.RequeueDeadLetter:output_type -> hookify.RequeueDeadLetterResponse
+	48, // 91: hookify.Hookify.PurgeDeadLetters:output_type -> hookify.PurgeDeadLettersResponse
+	51, // 92: hookify.Hookify.CreateApplication:output_type -> hookify.CreateApplicationResponse
+	53, // 93: hookify.Hookify.GetApplication:output_type -> hookify.GetApplicationResponse
+	55, // 94: hookify.Hookify.DeleteApplication:output_type -> hookify.DeleteApplicationResponse
+	58, // 95: hookify.Hookify.PublishMessage:output_type -> hookify.PublishMessageResponse
+	60, // 96: hookify.Hookify.GetMessage:output_type -> hookify.GetMessageResponse
+	72, // [72:97] is the sub-list for method output_type
+	47, // [47:72] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_hookify_proto_init() }
@@ -288,18 +3960,20 @@ func file_hookify_proto_init() {
 	if File_hookify_proto != nil {
 		return
 	}
+	file_hookify_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hookify_proto_rawDesc), len(file_hookify_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      4,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_hookify_proto_goTypes,
 		DependencyIndexes: file_hookify_proto_depIdxs,
+		EnumInfos:         file_hookify_proto_enumTypes,
 		MessageInfos:      file_hookify_proto_msgTypes,
 	}.Build()
 	File_hookify_proto = out.File
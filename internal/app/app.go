@@ -3,7 +3,9 @@ package app
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"sync"
 	"time"
 
 	"hookify/internal/config"
@@ -13,15 +15,49 @@ import (
 	"hookify/internal/storage/postgres"
 
 	grpcapp "hookify/internal/app/grpcapp"
+	httpapp "hookify/internal/app/httpapp"
 )
 
+// idempotencyCleanupInterval is how often expired idempotency keys are deleted.
+const idempotencyCleanupInterval = time.Hour
+
+// server is an API server run by the app.
+type server interface {
+	Run() error
+	Stop()
+}
+
+// consumer is the Kafka consumer run by the app.
+type consumer interface {
+	Run(ctx context.Context) error
+	Close() error
+}
+
+type outboxStorage interface {
+	ListenOutbox(ctx context.Context) (<-chan struct{}, error)
+	Close() error
+}
+
+type outboxWorker interface {
+	RunOutboxWorker(ctx context.Context, interval time.Duration, workers int, lease time.Duration, wakeups <-chan struct{})
+}
+
+type idempotencyKeyCleaner interface {
+	RunIdempotencyKeyCleanup(ctx context.Context, interval time.Duration)
+}
+
 type App struct {
 	log             *slog.Logger
-	grpcServer      *grpcapp.Server
-	consumer        *kafka.Consumer
-	producer        *kafka.Producer
-	storage         *postgres.Storage
-	deliveryService *delivery.Service
+	grpcServer      server
+	httpServer      server
+	consumer        consumer
+	producer        io.Closer
+	storage         outboxStorage
+	deliveryService outboxWorker
+	hookifyService  idempotencyKeyCleaner
+	outboxWorkers   int
+	outboxLease     time.Duration
+	outboxPoll      time.Duration
 }
 
 func New(log *slog.Logger, cfg config.Config) (*App, error) {
@@ -31,46 +67,90 @@ func New(log *slog.Logger, cfg config.Config) (*App, error) {
 	}
 
 	producer := kafka.NewProducer(log, cfg.KafkaBrokers, cfg.KafkaTopic)
-	hookifyService := hookify.New(log, storage, storage)
+	hookifyService := hookify.New(log, storage, storage, storage, storage, storage, cfg.SecretGracePeriod, cfg.ReplayRate, cfg.IdempotencyTTL)
 
-	gRPCServer := grpcapp.New(log, hookifyService, cfg.GRPCPort)
-	deliveryService := delivery.New(log, storage, storage, storage, producer)
-	consumer := kafka.NewConsumer(log, cfg.KafkaBrokers, cfg.KafkaTopic, cfg.KafkaGroupID, deliveryService, cfg.ConsumerWorkers)
+	gRPCServer := grpcapp.New(log, hookifyService, cfg.EgressPolicy, cfg.GRPCPort, cfg.RequestTimeout, map[string]grpcapp.Pinger{
+		"postgres": storage,
+		"kafka":    producer,
+	})
+	httpServer, err := httpapp.New(log, hookifyService, cfg.EgressPolicy, cfg.HTTPPort, cfg.RequestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP server: %w", err)
+	}
+	deliveryService := delivery.New(log, storage, storage, storage, storage, producer, storage, storage, storage, cfg.RetryPolicy, cfg.CircuitPolicy, cfg.EgressPolicy)
+	consumer := kafka.NewConsumer(log, cfg.KafkaBrokers, cfg.KafkaTopic, cfg.KafkaGroupID, cfg.KafkaDLQTopic, deliveryService, storage, cfg.ConsumerRetry, cfg.ConsumerWorkers)
 
 	return &App{
 		log:             log,
 		grpcServer:      gRPCServer,
+		httpServer:      httpServer,
 		consumer:        consumer,
 		producer:        producer,
 		storage:         storage,
 		deliveryService: deliveryService,
+		hookifyService:  hookifyService,
+		outboxWorkers:   cfg.OutboxWorkers,
+		outboxLease:     cfg.OutboxLease,
+		outboxPoll:      cfg.OutboxPollInterval,
 	}, nil
 }
 
+// Run serves and processes events until ctx is done or a server or the
+// background work stops, then shuts the app down.
 func (a *App) Run(ctx context.Context) error {
-	errCh := make(chan error, 3)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 4)
+
+	wakeups, err := a.storage.ListenOutbox(ctx)
+	if err != nil {
+		a.log.Error("failed to listen for outbox notifications, falling back to polling", "error", err)
+	}
 
 	go func() { errCh <- a.grpcServer.Run() }()
-	go func() { errCh <- a.consumer.Run(ctx) }()
+	go func() { errCh <- a.httpServer.Run() }()
+
+	var background sync.WaitGroup
+	background.Add(3)
+	go func() {
+		defer background.Done()
+		errCh <- a.consumer.Run(ctx)
+	}()
 	go func() {
-		a.deliveryService.RunOutboxWorker(ctx, time.Second)
+		defer background.Done()
+		a.hookifyService.RunIdempotencyKeyCleanup(ctx, idempotencyCleanupInterval)
+	}()
+	go func() {
+		defer background.Done()
+		a.deliveryService.RunOutboxWorker(ctx, a.outboxPoll, a.outboxWorkers, a.outboxLease, wakeups)
 		errCh <- nil
 	}()
 
+	var runErr error
 	select {
 	case <-ctx.Done():
-		a.Stop()
-		return nil
-	case err := <-errCh:
-		a.Stop()
-		return err
+	case runErr = <-errCh:
 	}
+
+	cancel()
+	a.stop(&background)
+	return runErr
 }
 
-func (a *App) Stop() {
+// stop shuts the app down. The servers stop taking requests first; Kafka and
+// Postgres are closed only once the background work has returned, so the
+// consumer can still commit the offsets of the messages it finished.
+func (a *App) stop(background *sync.WaitGroup) {
 	if a.grpcServer != nil {
 		a.grpcServer.Stop()
 	}
+	if a.httpServer != nil {
+		a.httpServer.Stop()
+	}
+
+	background.Wait()
+
 	if a.consumer != nil {
 		if err := a.consumer.Close(); err != nil {
 			a.log.Error("failed to close kafka consumer", "error", err)
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// closeRecorder records the order in which the app closes its dependencies.
+type closeRecorder struct {
+	mu     sync.Mutex
+	closed []string
+}
+
+func (r *closeRecorder) close(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = append(r.closed, name)
+}
+
+func (r *closeRecorder) isClosed(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range r.closed {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+type serverMock struct {
+	stop chan struct{}
+	once sync.Once
+}
+
+func newServerMock() *serverMock {
+	return &serverMock{stop: make(chan struct{})}
+}
+
+func (s *serverMock) Run() error {
+	<-s.stop
+	return nil
+}
+
+func (s *serverMock) Stop() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+// consumerMock holds messages in flight until the run is cancelled, then
+// finishes them and commits their offsets, as the Kafka consumer does while it
+// drains.
+type consumerMock struct {
+	closes    *closeRecorder
+	inFlight  []int64
+	committed []int64
+	commitErr error
+}
+
+func (c *consumerMock) Run(ctx context.Context) error {
+	<-ctx.Done()
+	time.Sleep(10 * time.Millisecond)
+	for _, offset := range c.inFlight {
+		if c.closes.isClosed("consumer") {
+			c.commitErr = errors.New("commit on a closed reader")
+			return nil
+		}
+		c.committed = append(c.committed, offset)
+	}
+	return nil
+}
+
+func (c *consumerMock) Close() error {
+	c.closes.close("consumer")
+	return nil
+}
+
+type closerMock struct {
+	name   string
+	closes *closeRecorder
+}
+
+func (c *closerMock) Close() error {
+	c.closes.close(c.name)
+	return nil
+}
+
+type storageMock struct {
+	closerMock
+}
+
+func (s *storageMock) ListenOutbox(ctx context.Context) (<-chan struct{}, error) {
+	return nil, nil
+}
+
+// outboxWorkerMock fails if storage is closed before it returns.
+type outboxWorkerMock struct {
+	closes       *closeRecorder
+	usedAfterEnd bool
+}
+
+func (w *outboxWorkerMock) RunOutboxWorker(ctx context.Context, interval time.Duration, workers int, lease time.Duration, wakeups <-chan struct{}) {
+	<-ctx.Done()
+	time.Sleep(10 * time.Millisecond)
+	w.usedAfterEnd = w.closes.isClosed("storage")
+}
+
+type cleanerMock struct{}
+
+func (cleanerMock) RunIdempotencyKeyCleanup(ctx context.Context, interval time.Duration) {
+	<-ctx.Done()
+}
+
+func TestRun_CommitsInFlightOffsetsBeforeClosing(t *testing.T) {
+	closes := &closeRecorder{}
+	consumer := &consumerMock{closes: closes, inFlight: []int64{4, 5, 6}}
+	worker := &outboxWorkerMock{closes: closes}
+	a := &App{
+		log:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		grpcServer:      newServerMock(),
+		httpServer:      newServerMock(),
+		consumer:        consumer,
+		producer:        &closerMock{name: "producer", closes: closes},
+		storage:         &storageMock{closerMock{name: "storage", closes: closes}},
+		deliveryService: worker,
+		hookifyService:  cleanerMock{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.Run(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected Run to return after cancellation")
+	}
+
+	if consumer.commitErr != nil || len(consumer.committed) != 3 {
+		t.Fatalf("expected in-flight offsets to be committed, got %v (%v)", consumer.committed, consumer.commitErr)
+	}
+	if worker.usedAfterEnd {
+		t.Fatalf("expected storage to stay open until the outbox worker returned")
+	}
+	if len(closes.closed) != 3 || closes.closed[0] != "consumer" || closes.closed[2] != "storage" {
+		t.Fatalf("unexpected close order %v", closes.closed)
+	}
+}
+
+func TestRun_ServerFailureStopsBackgroundWork(t *testing.T) {
+	closes := &closeRecorder{}
+	consumer := &consumerMock{closes: closes, inFlight: []int64{1}}
+	a := &App{
+		log:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		grpcServer:      &failingServerMock{err: errors.New("listen failed")},
+		httpServer:      newServerMock(),
+		consumer:        consumer,
+		producer:        &closerMock{name: "producer", closes: closes},
+		storage:         &storageMock{closerMock{name: "storage", closes: closes}},
+		deliveryService: &outboxWorkerMock{closes: closes},
+		hookifyService:  cleanerMock{},
+	}
+
+	if err := a.Run(context.Background()); err == nil || err.Error() != "listen failed" {
+		t.Fatalf("expected the server error, got %v", err)
+	}
+	if len(consumer.committed) != 1 {
+		t.Fatalf("expected in-flight offsets to be committed, got %v", consumer.committed)
+	}
+}
+
+type failingServerMock struct {
+	err error
+}
+
+func (s *failingServerMock) Run() error { return s.err }
+
+func (s *failingServerMock) Stop() {}
@@ -1,29 +1,70 @@
 package grpcapp
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
+	pb "hookify/gen/hookify"
+	"hookify/internal/models"
 	"hookify/internal/transport/grpcapi"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
+const (
+	// readinessInterval is how often the dependencies are checked.
+	readinessInterval = 10 * time.Second
+	// readinessTimeout bounds a single dependency check.
+	readinessTimeout = 5 * time.Second
+)
+
+// Pinger reports whether a dependency of the server is reachable.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
 type Server struct {
-	log        *slog.Logger
-	gRPCServer *grpc.Server
-	port       int
-}
-
-func New(log *slog.Logger, webhookAPI grpcapi.WebhookAPI, port int) *Server {
-	gRPCServer := grpc.NewServer()
-	grpcapi.Register(gRPCServer, webhookAPI, log)
-	return &Server{
-		log:        log,
-		gRPCServer: gRPCServer,
-		port:       port,
+	log          *slog.Logger
+	gRPCServer   *grpc.Server
+	health       *health.Server
+	dependencies map[string]Pinger
+	port         int
+	ctx          context.Context
+	cancel       context.CancelFunc
+}
+
+// New creates a server exposing webhookAPI together with the grpc.health.v1
+// service and server reflection. Every RPC except client streams must
+// complete within requestTimeout. The server is reported as serving only
+// while all of dependencies, keyed by name, are reachable.
+func New(log *slog.Logger, webhookAPI grpcapi.WebhookAPI, egressPolicy models.EgressPolicy, port int, requestTimeout time.Duration, dependencies map[string]Pinger) *Server {
+	gRPCServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcapi.UnaryInterceptors(log, requestTimeout)...),
+		grpc.ChainStreamInterceptor(grpcapi.StreamInterceptors(log, requestTimeout)...),
+	)
+	grpcapi.Register(gRPCServer, webhookAPI, egressPolicy, log)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
+	reflection.Register(gRPCServer)
+
+	s := &Server{
+		log:          log,
+		gRPCServer:   gRPCServer,
+		health:       healthServer,
+		dependencies: dependencies,
+		port:         port,
 	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return s
 }
 
 func (s *Server) Run() error {
@@ -35,6 +76,8 @@ func (s *Server) Run() error {
 		return fmt.Errorf("%s: failed to listen on port %d: %w", op, s.port, err)
 	}
 
+	go s.watchReadiness(s.ctx)
+
 	log.Info("gRPC server is started", slog.Int("port", s.port))
 
 	if err := s.gRPCServer.Serve(l); err != nil {
@@ -47,5 +90,48 @@ func (s *Server) Run() error {
 func (s *Server) Stop() {
 	const op = "grpcapp.Stop"
 	s.log.With(slog.String("op", op)).Info("stopping gRPC server", slog.Int("port", s.port))
+	s.cancel()
+	s.health.Shutdown()
 	s.gRPCServer.GracefulStop()
 }
+
+// watchReadiness checks the dependencies every readinessInterval until ctx
+// is done.
+func (s *Server) watchReadiness(ctx context.Context) {
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+
+	for {
+		s.checkReadiness(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReadiness reports the server as serving when every dependency
+// answers within readinessTimeout and as not serving otherwise.
+func (s *Server) checkReadiness(ctx context.Context) {
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	for name, dependency := range s.dependencies {
+		pingCtx, cancel := context.WithTimeout(ctx, readinessTimeout)
+		err := dependency.Ping(pingCtx)
+		cancel()
+		if err != nil {
+			s.log.Warn("dependency is unreachable", slog.String("dependency", name), slog.Any("error", err))
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	s.setServingStatus(servingStatus)
+}
+
+// setServingStatus reports servingStatus for the server as a whole and for
+// the Hookify service.
+func (s *Server) setServingStatus(servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", servingStatus)
+	s.health.SetServingStatus(pb.Hookify_ServiceDesc.ServiceName, servingStatus)
+}
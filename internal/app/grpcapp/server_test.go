@@ -0,0 +1,57 @@
+package grpcapp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "hookify/gen/hookify"
+	"hookify/internal/models"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type pingerMock struct {
+	err error
+}
+
+func (m *pingerMock) Ping(ctx context.Context) error {
+	return m.err
+}
+
+func servingStatus(t *testing.T, s *Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := s.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	return resp.Status
+}
+
+func TestCheckReadiness_FollowsDependencies(t *testing.T) {
+	kafka := &pingerMock{}
+	s := New(slog.New(slog.NewTextHandler(io.Discard, nil)), nil, models.EgressPolicy{}, 0, time.Minute, map[string]Pinger{
+		"postgres": &pingerMock{},
+		"kafka":    kafka,
+	})
+
+	if got := servingStatus(t, s, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING before the first check, got %v", got)
+	}
+
+	s.checkReadiness(context.Background())
+	for _, service := range []string{"", pb.Hookify_ServiceDesc.ServiceName} {
+		if got := servingStatus(t, s, service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("expected %q SERVING, got %v", service, got)
+		}
+	}
+
+	kafka.err = errors.New("connection refused")
+	s.checkReadiness(context.Background())
+	if got := servingStatus(t, s, pb.Hookify_ServiceDesc.ServiceName); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING with kafka unreachable, got %v", got)
+	}
+}
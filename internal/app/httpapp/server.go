@@ -0,0 +1,69 @@
+package httpapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"hookify/internal/models"
+	"hookify/internal/transport/grpcapi"
+)
+
+// shutdownTimeout bounds how long Stop waits for in-flight requests.
+const shutdownTimeout = 10 * time.Second
+
+type Server struct {
+	log        *slog.Logger
+	httpServer *http.Server
+	port       int
+}
+
+func New(log *slog.Logger, webhookAPI grpcapi.WebhookAPI, egressPolicy models.EgressPolicy, port int, requestTimeout time.Duration) (*Server, error) {
+	gateway, err := grpcapi.NewGateway(webhookAPI, egressPolicy, log, requestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP gateway: %w", err)
+	}
+
+	return &Server{
+		log: log,
+		httpServer: &http.Server{
+			Handler:           gateway,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		port: port,
+	}, nil
+}
+
+func (s *Server) Run() error {
+	const op = "httpapp.Run"
+	log := s.log.With(slog.String("op", op))
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return fmt.Errorf("%s: failed to listen on port %d: %w", op, s.port, err)
+	}
+
+	log.Info("HTTP server is started", slog.Int("port", s.port))
+
+	if err := s.httpServer.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: failed to serve HTTP server: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Server) Stop() {
+	const op = "httpapp.Stop"
+	log := s.log.With(slog.String("op", op))
+	log.Info("stopping HTTP server", slog.Int("port", s.port))
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		log.Error("failed to shut down HTTP server", "error", err)
+	}
+}
@@ -6,18 +6,34 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"hookify/internal/models"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Env             string
-	PostgresDSN     string
-	KafkaBrokers    []string
-	KafkaTopic      string
-	KafkaGroupID    string
-	GRPCPort        int
-	ConsumerWorkers int
+	Env                string
+	PostgresDSN        string
+	KafkaBrokers       []string
+	KafkaTopic         string
+	KafkaGroupID       string
+	KafkaDLQTopic      string
+	GRPCPort           int
+	HTTPPort           int
+	RequestTimeout     time.Duration
+	ConsumerWorkers    int
+	ConsumerRetry      models.RetryPolicy
+	OutboxWorkers      int
+	OutboxLease        time.Duration
+	OutboxPollInterval time.Duration
+	SecretGracePeriod  time.Duration
+	ReplayRate         float64
+	IdempotencyTTL     time.Duration
+	RetryPolicy        models.RetryPolicy
+	CircuitPolicy      models.CircuitPolicy
+	EgressPolicy       models.EgressPolicy
 }
 
 func Load() (Config, error) {
@@ -61,6 +77,11 @@ func Load() (Config, error) {
 		return Config{}, errors.New("HOOKIFY_KAFKA_GROUP_ID is required")
 	}
 
+	dlqTopic := strings.TrimSpace(os.Getenv("HOOKIFY_KAFKA_DLQ_TOPIC"))
+	if dlqTopic != "" && dlqTopic == topic {
+		return Config{}, errors.New("HOOKIFY_KAFKA_DLQ_TOPIC must differ from HOOKIFY_KAFKA_TOPIC")
+	}
+
 	grpcPort := 50051
 	if v := strings.TrimSpace(os.Getenv("HOOKIFY_GRPC_PORT")); v != "" {
 		p, err := strconv.Atoi(v)
@@ -70,6 +91,22 @@ func Load() (Config, error) {
 		grpcPort = p
 	}
 
+	httpPort, err := intEnv("HOOKIFY_HTTP_PORT", 8080)
+	if err != nil {
+		return Config{}, err
+	}
+	if httpPort == grpcPort {
+		return Config{}, errors.New("HOOKIFY_HTTP_PORT must differ from HOOKIFY_GRPC_PORT")
+	}
+
+	requestTimeout, err := durationEnv("HOOKIFY_REQUEST_TIMEOUT", time.Minute)
+	if err != nil {
+		return Config{}, err
+	}
+	if requestTimeout <= 0 {
+		return Config{}, errors.New("HOOKIFY_REQUEST_TIMEOUT must be > 0")
+	}
+
 	workers := 1
 	if v := strings.TrimSpace(os.Getenv("HOOKIFY_CONSUMER_WORKERS")); v != "" {
 		w, err := strconv.Atoi(v)
@@ -82,13 +119,264 @@ func Load() (Config, error) {
 		workers = w
 	}
 
+	outboxWorkers, err := intEnv("HOOKIFY_OUTBOX_WORKERS", 1)
+	if err != nil {
+		return Config{}, err
+	}
+	if outboxWorkers <= 0 {
+		return Config{}, errors.New("HOOKIFY_OUTBOX_WORKERS must be > 0")
+	}
+
+	outboxLease, err := durationEnv("HOOKIFY_OUTBOX_LEASE", 5*time.Minute)
+	if err != nil {
+		return Config{}, err
+	}
+	if outboxLease <= 0 {
+		return Config{}, errors.New("HOOKIFY_OUTBOX_LEASE must be > 0")
+	}
+
+	outboxPollInterval, err := durationEnv("HOOKIFY_OUTBOX_POLL_INTERVAL", 10*time.Second)
+	if err != nil {
+		return Config{}, err
+	}
+	if outboxPollInterval <= 0 {
+		return Config{}, errors.New("HOOKIFY_OUTBOX_POLL_INTERVAL must be > 0")
+	}
+
+	secretGracePeriod, err := durationEnv("HOOKIFY_SECRET_GRACE_PERIOD", 24*time.Hour)
+	if err != nil {
+		return Config{}, err
+	}
+	if secretGracePeriod < 0 {
+		return Config{}, errors.New("HOOKIFY_SECRET_GRACE_PERIOD must be >= 0")
+	}
+
+	replayRate, err := floatEnv("HOOKIFY_REPLAY_RATE", 10)
+	if err != nil {
+		return Config{}, err
+	}
+	if replayRate < models.MinReplayRate || replayRate > models.MaxReplayRate {
+		return Config{}, fmt.Errorf("HOOKIFY_REPLAY_RATE must be between %g and %g", models.MinReplayRate, models.MaxReplayRate)
+	}
+
+	idempotencyTTL, err := durationEnv("HOOKIFY_IDEMPOTENCY_TTL", 24*time.Hour)
+	if err != nil {
+		return Config{}, err
+	}
+	if idempotencyTTL <= 0 {
+		return Config{}, errors.New("HOOKIFY_IDEMPOTENCY_TTL must be > 0")
+	}
+
+	retryPolicy, err := loadRetryPolicy()
+	if err != nil {
+		return Config{}, err
+	}
+
+	consumerRetry, err := loadConsumerRetryPolicy()
+	if err != nil {
+		return Config{}, err
+	}
+
+	circuitPolicy, err := loadCircuitPolicy()
+	if err != nil {
+		return Config{}, err
+	}
+
+	egressPolicy, err := loadEgressPolicy()
+	if err != nil {
+		return Config{}, err
+	}
+
 	return Config{
-		Env:             env,
-		PostgresDSN:     postgresDSN,
-		KafkaBrokers:    brokers,
-		KafkaTopic:      topic,
-		KafkaGroupID:    groupID,
-		GRPCPort:        grpcPort,
-		ConsumerWorkers: workers,
+		Env:                env,
+		PostgresDSN:        postgresDSN,
+		KafkaBrokers:       brokers,
+		KafkaTopic:         topic,
+		KafkaGroupID:       groupID,
+		KafkaDLQTopic:      dlqTopic,
+		GRPCPort:           grpcPort,
+		HTTPPort:           httpPort,
+		RequestTimeout:     requestTimeout,
+		ConsumerWorkers:    workers,
+		ConsumerRetry:      consumerRetry,
+		OutboxWorkers:      outboxWorkers,
+		OutboxLease:        outboxLease,
+		OutboxPollInterval: outboxPollInterval,
+		SecretGracePeriod:  secretGracePeriod,
+		ReplayRate:         replayRate,
+		IdempotencyTTL:     idempotencyTTL,
+		RetryPolicy:        retryPolicy,
+		CircuitPolicy:      circuitPolicy,
+		EgressPolicy:       egressPolicy,
 	}, nil
 }
+
+func loadRetryPolicy() (models.RetryPolicy, error) {
+	var (
+		p   models.RetryPolicy
+		err error
+	)
+
+	if p.InitialInterval, err = durationEnv("HOOKIFY_RETRY_INITIAL_INTERVAL", 5*time.Second); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.Multiplier, err = floatEnv("HOOKIFY_RETRY_MULTIPLIER", 2); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.MaxInterval, err = durationEnv("HOOKIFY_RETRY_MAX_INTERVAL", time.Hour); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.Jitter, err = floatEnv("HOOKIFY_RETRY_JITTER", 0.2); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.MaxAttempts, err = intEnv("HOOKIFY_RETRY_MAX_ATTEMPTS", 0); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.MaxAge, err = durationEnv("HOOKIFY_RETRY_MAX_AGE", 24*time.Hour); err != nil {
+		return models.RetryPolicy{}, err
+	}
+
+	if err := p.Validate(); err != nil {
+		return models.RetryPolicy{}, fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	return p, nil
+}
+
+// loadConsumerRetryPolicy loads how the consumer retries a failing message in
+// place before dead-lettering it. It blocks the message's partition meanwhile,
+// so the number of attempts is always bounded.
+func loadConsumerRetryPolicy() (models.RetryPolicy, error) {
+	p := models.RetryPolicy{Multiplier: 2, Jitter: 0.2}
+	var err error
+
+	if p.InitialInterval, err = durationEnv("HOOKIFY_CONSUMER_RETRY_INITIAL_INTERVAL", time.Second); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.MaxInterval, err = durationEnv("HOOKIFY_CONSUMER_RETRY_MAX_INTERVAL", 30*time.Second); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.MaxAttempts, err = intEnv("HOOKIFY_CONSUMER_RETRY_MAX_ATTEMPTS", 5); err != nil {
+		return models.RetryPolicy{}, err
+	}
+	if p.MaxAttempts < 1 {
+		return models.RetryPolicy{}, errors.New("HOOKIFY_CONSUMER_RETRY_MAX_ATTEMPTS must be > 0")
+	}
+
+	if err := p.Validate(); err != nil {
+		return models.RetryPolicy{}, fmt.Errorf("invalid consumer retry policy: %w", err)
+	}
+
+	return p, nil
+}
+
+func loadCircuitPolicy() (models.CircuitPolicy, error) {
+	var (
+		p   models.CircuitPolicy
+		err error
+	)
+
+	if p.FailureThreshold, err = intEnv("HOOKIFY_CIRCUIT_FAILURE_THRESHOLD", 5); err != nil {
+		return models.CircuitPolicy{}, err
+	}
+	if p.Cooldown, err = durationEnv("HOOKIFY_CIRCUIT_COOLDOWN", time.Minute); err != nil {
+		return models.CircuitPolicy{}, err
+	}
+	if p.DisableThreshold, err = intEnv("HOOKIFY_CIRCUIT_DISABLE_THRESHOLD", 100); err != nil {
+		return models.CircuitPolicy{}, err
+	}
+
+	if err := p.Validate(); err != nil {
+		return models.CircuitPolicy{}, fmt.Errorf("invalid circuit breaker policy: %w", err)
+	}
+
+	return p, nil
+}
+
+func loadEgressPolicy() (models.EgressPolicy, error) {
+	var p models.EgressPolicy
+
+	for _, scheme := range listEnv("HOOKIFY_EGRESS_ALLOWED_SCHEMES", "http,https") {
+		scheme = strings.ToLower(scheme)
+		if scheme != "http" && scheme != "https" {
+			return models.EgressPolicy{}, fmt.Errorf("invalid HOOKIFY_EGRESS_ALLOWED_SCHEMES: unsupported scheme %q", scheme)
+		}
+		p.AllowedSchemes = append(p.AllowedSchemes, scheme)
+	}
+
+	// Every port is allowed by default so webhooks registered on other ports
+	// keep working; private networks are refused regardless.
+	for _, v := range listEnv("HOOKIFY_EGRESS_ALLOWED_PORTS", "*") {
+		port, err := strconv.Atoi(v)
+		if err != nil || port < 1 || port > 65535 {
+			return models.EgressPolicy{}, fmt.Errorf("invalid HOOKIFY_EGRESS_ALLOWED_PORTS: bad port %q", v)
+		}
+		p.AllowedPorts = append(p.AllowedPorts, port)
+	}
+
+	if v := strings.TrimSpace(os.Getenv("HOOKIFY_EGRESS_ALLOW_PRIVATE_NETWORKS")); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return models.EgressPolicy{}, fmt.Errorf("invalid HOOKIFY_EGRESS_ALLOW_PRIVATE_NETWORKS: %w", err)
+		}
+		p.AllowPrivateNetworks = allow
+	}
+
+	return p, nil
+}
+
+// listEnv splits a comma-separated variable, dropping empty items. Setting the
+// variable to "*" yields no items, which policies read as "no restriction".
+func listEnv(name string, def string) []string {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		v = def
+	}
+	if v == "*" {
+		return nil
+	}
+
+	var items []string
+	for _, part := range strings.Split(v, ",") {
+		if item := strings.TrimSpace(part); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func durationEnv(name string, def time.Duration) (time.Duration, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return d, nil
+}
+
+func floatEnv(name string, def float64) (float64, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return f, nil
+}
+
+func intEnv(name string, def int) (int, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return i, nil
+}
@@ -1,6 +1,11 @@
 package config
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"hookify/internal/models"
+)
 
 func setBaseEnv(t *testing.T) {
 	t.Helper()
@@ -26,12 +31,24 @@ func TestLoad_OK(t *testing.T) {
 	if cfg.GRPCPort != 50051 {
 		t.Fatalf("expected GRPCPort=50051, got %d", cfg.GRPCPort)
 	}
+	if cfg.HTTPPort != 8080 {
+		t.Fatalf("expected HTTPPort=8080, got %d", cfg.HTTPPort)
+	}
+	if cfg.RequestTimeout != time.Minute {
+		t.Fatalf("expected RequestTimeout=1m, got %v", cfg.RequestTimeout)
+	}
 	if cfg.ConsumerWorkers != 1 {
 		t.Fatalf("expected ConsumerWorkers=1, got %d", cfg.ConsumerWorkers)
 	}
 	if len(cfg.KafkaBrokers) != 2 {
 		t.Fatalf("expected 2 kafka brokers, got %d", len(cfg.KafkaBrokers))
 	}
+	if cfg.SecretGracePeriod != 24*time.Hour {
+		t.Fatalf("expected SecretGracePeriod=24h, got %v", cfg.SecretGracePeriod)
+	}
+	if cfg.RetryPolicy.InitialInterval != 5*time.Second || cfg.RetryPolicy.MaxAge != 24*time.Hour {
+		t.Fatalf("unexpected default retry policy: %#v", cfg.RetryPolicy)
+	}
 }
 
 func TestLoad_EnvProduction(t *testing.T) {
@@ -81,3 +98,225 @@ func TestLoad_BrokersParsingTrims(t *testing.T) {
 		t.Fatalf("unexpected brokers: %#v", cfg.KafkaBrokers)
 	}
 }
+
+func TestLoad_InvalidSecretGracePeriod(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_SECRET_GRACE_PERIOD", "soon")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_DLQTopicMustDifferFromTopic(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_KAFKA_DLQ_TOPIC", "topic")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_HTTPPortMustDifferFromGRPCPort(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_HTTP_PORT", "50051")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_InvalidRequestTimeout(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_REQUEST_TIMEOUT", "0s")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_ConsumerRetryMustBeBounded(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_CONSUMER_RETRY_MAX_ATTEMPTS", "0")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_InvalidReplayRate(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_REPLAY_RATE", "0")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_ReplayRateTooLow(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_REPLAY_RATE", "1e-12")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_InvalidIdempotencyTTL(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_IDEMPOTENCY_TTL", "0s")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_RetryPolicyOverrides(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_RETRY_INITIAL_INTERVAL", "1s")
+	t.Setenv("HOOKIFY_RETRY_MULTIPLIER", "3")
+	t.Setenv("HOOKIFY_RETRY_MAX_INTERVAL", "10m")
+	t.Setenv("HOOKIFY_RETRY_JITTER", "0")
+	t.Setenv("HOOKIFY_RETRY_MAX_ATTEMPTS", "8")
+	t.Setenv("HOOKIFY_RETRY_MAX_AGE", "2h")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	p := cfg.RetryPolicy
+	if p.InitialInterval != time.Second || p.Multiplier != 3 || p.MaxInterval != 10*time.Minute || p.Jitter != 0 || p.MaxAttempts != 8 || p.MaxAge != 2*time.Hour {
+		t.Fatalf("unexpected retry policy: %#v", p)
+	}
+}
+
+func TestLoad_InvalidRetryPolicy(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_RETRY_JITTER", "1.5")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_CircuitPolicyOverrides(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_CIRCUIT_FAILURE_THRESHOLD", "3")
+	t.Setenv("HOOKIFY_CIRCUIT_COOLDOWN", "30s")
+	t.Setenv("HOOKIFY_CIRCUIT_DISABLE_THRESHOLD", "0")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	want := models.CircuitPolicy{FailureThreshold: 3, Cooldown: 30 * time.Second, DisableThreshold: 0}
+	if cfg.CircuitPolicy != want {
+		t.Fatalf("unexpected circuit policy: %#v", cfg.CircuitPolicy)
+	}
+}
+
+func TestLoad_InvalidCircuitPolicy(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_CIRCUIT_FAILURE_THRESHOLD", "10")
+	t.Setenv("HOOKIFY_CIRCUIT_DISABLE_THRESHOLD", "5")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_EgressPolicyDefaults(t *testing.T) {
+	setBaseEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	p := cfg.EgressPolicy
+	if len(p.AllowedSchemes) != 2 || p.AllowedPorts != nil || p.AllowPrivateNetworks {
+		t.Fatalf("unexpected egress policy: %#v", p)
+	}
+}
+
+func TestLoad_EgressPolicyOverrides(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_EGRESS_ALLOWED_SCHEMES", "HTTPS")
+	t.Setenv("HOOKIFY_EGRESS_ALLOWED_PORTS", "443, 8443")
+	t.Setenv("HOOKIFY_EGRESS_ALLOW_PRIVATE_NETWORKS", "true")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	p := cfg.EgressPolicy
+	if len(p.AllowedSchemes) != 1 || p.AllowedSchemes[0] != "https" || len(p.AllowedPorts) != 2 || p.AllowedPorts[1] != 8443 || !p.AllowPrivateNetworks {
+		t.Fatalf("unexpected egress policy: %#v", p)
+	}
+}
+
+func TestLoad_InvalidEgressPolicy(t *testing.T) {
+	for name, value := range map[string]string{
+		"HOOKIFY_EGRESS_ALLOWED_SCHEMES":        "gopher",
+		"HOOKIFY_EGRESS_ALLOWED_PORTS":          "443,70000",
+		"HOOKIFY_EGRESS_ALLOW_PRIVATE_NETWORKS": "maybe",
+	} {
+		t.Run(name, func(t *testing.T) {
+			setBaseEnv(t)
+			t.Setenv(name, value)
+
+			if _, err := Load(); err == nil {
+				t.Fatalf("%s=%s: expected error", name, value)
+			}
+		})
+	}
+}
+
+func TestLoad_OutboxWorkers(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_OUTBOX_WORKERS", "4")
+	t.Setenv("HOOKIFY_OUTBOX_LEASE", "2m")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if cfg.OutboxWorkers != 4 || cfg.OutboxLease != 2*time.Minute {
+		t.Fatalf("unexpected outbox settings: workers=%d lease=%v", cfg.OutboxWorkers, cfg.OutboxLease)
+	}
+}
+
+func TestLoad_InvalidOutboxWorkers(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("HOOKIFY_OUTBOX_WORKERS", "0")
+
+	if _, err := Load(); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestLoad_OutboxPollInterval(t *testing.T) {
+	setBaseEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if cfg.OutboxPollInterval != 10*time.Second {
+		t.Fatalf("expected default poll interval of 10s, got %v", cfg.OutboxPollInterval)
+	}
+
+	t.Setenv("HOOKIFY_OUTBOX_POLL_INTERVAL", "0s")
+	if _, err := Load(); err == nil {
+		t.Fatalf("expected error for zero poll interval")
+	}
+}
@@ -0,0 +1,81 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hookify/internal/models"
+	"time"
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitStore interface {
+	GetCircuit(ctx context.Context, webhookID int64) (models.Circuit, error)
+	TryHalfOpenCircuit(ctx context.Context, webhookID int64, probeTimeout time.Duration) (bool, error)
+	RecordCircuitSuccess(ctx context.Context, webhookID int64) error
+	RecordCircuitFailure(ctx context.Context, webhookID int64, threshold int, cooldown time.Duration) (models.Circuit, error)
+}
+
+// acquireCircuit checks whether the webhook's circuit lets a delivery through.
+// An open circuit whose cooldown has elapsed lets exactly one probe through
+// across all replicas. A held delivery gets a retriable error carrying the
+// time left until the circuit may be probed.
+func (s *Service) acquireCircuit(ctx context.Context, webhookID int64) error {
+	circuit, err := s.circuits.GetCircuit(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to get circuit: %w", err)
+	}
+	if circuit.State == models.CircuitStateClosed {
+		return nil
+	}
+
+	probing, err := s.circuits.TryHalfOpenCircuit(ctx, webhookID, s.circuitPolicy.Cooldown)
+	if err != nil {
+		return fmt.Errorf("failed to half-open circuit: %w", err)
+	}
+	if probing {
+		s.log.Info("circuit half-open, probing endpoint", "webhook_id", webhookID)
+		return nil
+	}
+
+	retryAfter := s.circuitPolicy.Cooldown
+	if circuit.OpenUntil != nil && time.Until(*circuit.OpenUntil) > 0 {
+		retryAfter = time.Until(*circuit.OpenUntil)
+	}
+	return &DeliveryError{Kind: ErrorKindRetriable, RetryAfter: retryAfter, Err: errCircuitOpen}
+}
+
+// recordCircuit feeds the outcome of a delivery attempt into the webhook's
+// circuit. Only retriable failures count against the endpoint; a response
+// that is rejected permanently still proves it is reachable. Once the failure
+// streak reaches the disable threshold the webhook is disabled.
+func (s *Service) recordCircuit(ctx context.Context, webhook models.Webhook, deliveryErr error) {
+	if deliveryErr == nil {
+		if err := s.circuits.RecordCircuitSuccess(ctx, webhook.ID); err != nil {
+			s.log.Error("failed to record circuit success", "webhook_id", webhook.ID, "error", err)
+		}
+		return
+	}
+	if errorKind(deliveryErr) != ErrorKindRetriable {
+		return
+	}
+
+	circuit, err := s.circuits.RecordCircuitFailure(ctx, webhook.ID, s.circuitPolicy.FailureThreshold, s.circuitPolicy.Cooldown)
+	if err != nil {
+		s.log.Error("failed to record circuit failure", "webhook_id", webhook.ID, "error", err)
+		return
+	}
+	if circuit.State == models.CircuitStateOpen {
+		s.log.Warn("circuit open", "webhook_id", webhook.ID, "consecutive_failures", circuit.ConsecutiveFailures)
+	}
+
+	threshold := s.circuitPolicy.DisableThreshold
+	if threshold > 0 && circuit.ConsecutiveFailures >= threshold {
+		s.log.Warn("endpoint keeps failing, disabling webhook", "webhook_id", webhook.ID, "consecutive_failures", circuit.ConsecutiveFailures)
+		reason := fmt.Sprintf("%d consecutive delivery failures", circuit.ConsecutiveFailures)
+		if err := s.webhookDisabler.DisableWebhook(ctx, webhook.ID, reason); err != nil {
+			s.log.Error("failed to disable webhook", "webhook_id", webhook.ID, "error", err)
+		}
+	}
+}
@@ -3,20 +3,30 @@ package delivery
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"hookify/internal/models"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Service struct {
 	log                *slog.Logger
 	webhookProvider    WebhookProvider
+	webhookDisabler    WebhookDisabler
 	eventStatusUpdater EventStatusUpdater
 	outboxRepo         OutboxRepository
 	eventPublisher     EventPublisher
+	attemptRecorder    AttemptRecorder
+	circuits           CircuitStore
+	deadLetters        DeadLetterRepository
+	retryPolicy        models.RetryPolicy
+	circuitPolicy      models.CircuitPolicy
+	egressPolicy       models.EgressPolicy
 	httpClient         *http.Client
 }
 
@@ -24,31 +34,58 @@ type WebhookProvider interface {
 	GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error)
 }
 
+type WebhookDisabler interface {
+	DisableWebhook(ctx context.Context, webhookID int64, reason string) error
+}
+
 type EventStatusUpdater interface {
 	UpdateEventStatus(ctx context.Context, eventID int64, status models.EventStatus) error
 }
 
 type OutboxRepository interface {
-	GetDueOutboxEntries(ctx context.Context, limit int) ([]models.OutboxEntry, error)
+	ClaimDueOutboxEntries(ctx context.Context, workerID string, limit int, lease time.Duration) ([]models.OutboxEntry, error)
 	UpdateOutboxEntry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time) error
 	DeleteOutboxEntry(ctx context.Context, id int64) error
 	SaveOutboxEntry(ctx context.Context, eventID int64, webhookID int64, payload string, attempts int, nextAttemptAt time.Time, jobType models.OutboxType) (int64, error)
+	IsHeadOfLine(ctx context.Context, webhookID int64, eventID int64) (bool, error)
 }
 
 type EventPublisher interface {
 	PublishEvent(ctx context.Context, event models.RawEvent) error
 }
 
-func New(log *slog.Logger, webhookProvider WebhookProvider, eventStatusUpdater EventStatusUpdater, outboxRepo OutboxRepository, eventPublisher EventPublisher) *Service {
+type AttemptRecorder interface {
+	SaveDeliveryAttempt(ctx context.Context, attempt models.DeliveryAttempt) error
+}
+
+type DeadLetterRepository interface {
+	SaveDeadLetter(ctx context.Context, deadLetter models.DeadLetter) (int64, error)
+	DeadLetterOutboxEntry(ctx context.Context, entryID int64, deadLetter models.DeadLetter) error
+}
+
+// maxRecordedResponseBody caps how much of an endpoint's response is kept in the attempt history.
+const maxRecordedResponseBody = 4096
+
+// New creates the delivery service. retryPolicy applies to every outbox entry
+// unless the entry's webhook overrides it; circuitPolicy drives the
+// per-webhook circuit breakers kept in circuits. Work that is given up on is
+// kept in deadLetters. Deliveries only reach destinations allowed by
+// egressPolicy.
+func New(log *slog.Logger, webhookProvider WebhookProvider, webhookDisabler WebhookDisabler, eventStatusUpdater EventStatusUpdater, outboxRepo OutboxRepository, eventPublisher EventPublisher, attemptRecorder AttemptRecorder, circuits CircuitStore, deadLetters DeadLetterRepository, retryPolicy models.RetryPolicy, circuitPolicy models.CircuitPolicy, egressPolicy models.EgressPolicy) *Service {
 	return &Service{
 		log:                log,
 		webhookProvider:    webhookProvider,
+		webhookDisabler:    webhookDisabler,
 		eventStatusUpdater: eventStatusUpdater,
 		outboxRepo:         outboxRepo,
 		eventPublisher:     eventPublisher,
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
+		attemptRecorder:    attemptRecorder,
+		circuits:           circuits,
+		deadLetters:        deadLetters,
+		retryPolicy:        retryPolicy,
+		circuitPolicy:      circuitPolicy,
+		egressPolicy:       egressPolicy,
+		httpClient:         newHTTPClient(egressPolicy, 15*time.Second),
 	}
 }
 
@@ -60,10 +97,54 @@ func (s *Service) HandleEvent(ctx context.Context, event models.RawEvent) error
 		return fmt.Errorf("failed to get webhook: %w", err)
 	}
 
-	err = s.sendRequest(ctx, webhook.URL, webhook.Secret, event.Payload)
+	if !webhook.SubscribesTo(event.EventType) {
+		return s.filterEvent(ctx, event)
+	}
+
+	if webhook.Ordered {
+		head, err := s.outboxRepo.IsHeadOfLine(ctx, webhook.ID, event.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check delivery order: %w", err)
+		}
+		if !head {
+			// The outbox releases it once every earlier event is done.
+			s.log.Info("queueing ordered delivery behind earlier events", "event_id", event.ID, "webhook_id", event.WebhookID, "sequence", event.Sequence)
+			if _, err := s.outboxRepo.SaveOutboxEntry(ctx, event.ID, event.WebhookID, event.Payload, 0, time.Now(), models.OutboxTypeDelivery); err != nil {
+				return fmt.Errorf("failed to queue ordered delivery: %w", err)
+			}
+			return nil
+		}
+	}
+
+	err = s.deliver(ctx, webhook, event)
 	if err != nil {
+		policy := s.policyFor(webhook)
+		if isHeld(err) {
+			s.log.Info("holding delivery", "event_id", event.ID, "webhook_id", event.WebhookID, "reason", err)
+			if _, saveErr := s.outboxRepo.SaveOutboxEntry(ctx, event.ID, event.WebhookID, event.Payload, 0, time.Now().Add(retryDelay(policy, 0, err)), models.OutboxTypeDelivery); saveErr != nil {
+				return fmt.Errorf("failed to queue held delivery: %w", saveErr)
+			}
+			return nil
+		}
+		if kind := errorKind(err); kind != ErrorKindRetriable {
+			s.log.Error("failed to send request, not retrying", "event_id", event.ID, "kind", kind, "error", err)
+			s.saveDeadLetter(ctx, event, models.DeadLetterReasonPermanentFailure, err)
+			if updateErr := s.eventStatusUpdater.UpdateEventStatus(ctx, event.ID, models.EventStatusFailed); updateErr != nil {
+				return fmt.Errorf("failed to update event status: %w", updateErr)
+			}
+			return nil
+		}
+		if policy.Exhausted(1, 0) {
+			s.log.Error("failed to send request, retry policy allows no retries", "event_id", event.ID, "error", err)
+			s.saveDeadLetter(ctx, event, models.DeadLetterReasonRetriesExhausted, err)
+			if updateErr := s.eventStatusUpdater.UpdateEventStatus(ctx, event.ID, models.EventStatusFailed); updateErr != nil {
+				return fmt.Errorf("failed to update event status: %w", updateErr)
+			}
+			return nil
+		}
+
 		s.log.Error("failed to send request, queueing for retry", "error", err)
-		_, saveErr := s.outboxRepo.SaveOutboxEntry(ctx, event.ID, event.WebhookID, event.Payload, 0, time.Now().Add(5*time.Second), models.OutboxTypeDelivery)
+		_, saveErr := s.outboxRepo.SaveOutboxEntry(ctx, event.ID, event.WebhookID, event.Payload, 1, time.Now().Add(retryDelay(policy, 1, err)), models.OutboxTypeDelivery)
 		if saveErr != nil {
 			s.log.Error("failed to save outbox entry for delivery retry", "error", saveErr)
 			if updateErr := s.eventStatusUpdater.UpdateEventStatus(ctx, event.ID, models.EventStatusFailed); updateErr != nil {
@@ -83,22 +164,124 @@ func (s *Service) HandleEvent(ctx context.Context, event models.RawEvent) error
 	return nil
 }
 
-func (s *Service) sendRequest(ctx context.Context, url, secret, payload string) error {
-	r := bytes.NewReader([]byte(payload))
+// filterEvent marks an event the webhook is not subscribed to as filtered
+// instead of delivering it.
+func (s *Service) filterEvent(ctx context.Context, event models.RawEvent) error {
+	s.log.Info("webhook is not subscribed to event type, filtering", "event_id", event.ID, "webhook_id", event.WebhookID, "event_type", event.EventType)
+	if err := s.eventStatusUpdater.UpdateEventStatus(ctx, event.ID, models.EventStatusFiltered); err != nil {
+		return fmt.Errorf("failed to update event status: %w", err)
+	}
+	return nil
+}
+
+// saveDeadLetter keeps an event whose first delivery attempt was also its last.
+// Failing to save it is logged, as the event is marked failed regardless.
+func (s *Service) saveDeadLetter(ctx context.Context, event models.RawEvent, reason string, deliveryErr error) {
+	deadLetter := models.DeadLetter{
+		Source:    models.DeadLetterSourceOutbox,
+		EventID:   event.ID,
+		WebhookID: event.WebhookID,
+		Type:      models.OutboxTypeDelivery,
+		Payload:   event.Payload,
+		Reason:    reason,
+		LastError: deliveryErr.Error(),
+		Attempts:  1,
+	}
+	if _, err := s.deadLetters.SaveDeadLetter(ctx, deadLetter); err != nil {
+		s.log.Error("failed to save dead letter", "event_id", event.ID, "error", err)
+	}
+}
+
+// policyFor returns the webhook's retry policy override or the service default.
+func (s *Service) policyFor(webhook models.Webhook) models.RetryPolicy {
+	if webhook.RetryPolicy != nil {
+		return *webhook.RetryPolicy
+	}
+	return s.retryPolicy
+}
+
+// retryDelay returns how long to wait before retrying after attempts failed
+// attempts. An endpoint's Retry-After takes precedence over the policy backoff
+// but is capped at the policy's MaxInterval. A delivery held by a pause stays
+// due: the outbox skips it until the webhook is resumed.
+func retryDelay(policy models.RetryPolicy, attempts int, err error) time.Duration {
+	if errors.Is(err, errWebhookPaused) {
+		return 0
+	}
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) && deliveryErr.RetryAfter > 0 {
+		return min(deliveryErr.RetryAfter, policy.MaxInterval)
+	}
+	return policy.Backoff(attempts)
+}
+
+// deliver sends the payload to the webhook endpoint and records the attempt.
+// Failing to record the attempt is logged but does not fail the delivery.
+// Disabled and paused webhooks and webhooks with an open circuit are not
+// contacted, and an endpoint answering 410 Gone gets its webhook disabled.
+func (s *Service) deliver(ctx context.Context, webhook models.Webhook, event models.RawEvent) error {
+	if webhook.Status == models.WebhookStatusDisabled {
+		return &DeliveryError{Kind: ErrorKindPermanent, Err: errWebhookDisabled}
+	}
+	if webhook.Status == models.WebhookStatusPaused {
+		return &DeliveryError{Kind: ErrorKindRetriable, Err: errWebhookPaused}
+	}
+	if err := s.acquireCircuit(ctx, webhook.ID); err != nil {
+		return err
+	}
+
+	attempt, err := s.sendRequest(ctx, webhook, event)
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	if recordErr := s.attemptRecorder.SaveDeliveryAttempt(ctx, attempt); recordErr != nil {
+		s.log.Error("failed to record delivery attempt", "event_id", event.ID, "webhook_id", webhook.ID, "error", recordErr)
+	}
+
+	s.recordCircuit(ctx, webhook, err)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, r)
+	if errorKind(err) == ErrorKindGone {
+		s.log.Warn("endpoint is gone, disabling webhook", "webhook_id", webhook.ID, "url", webhook.URL)
+		if disableErr := s.webhookDisabler.DisableWebhook(ctx, webhook.ID, "endpoint responded 410 Gone"); disableErr != nil {
+			s.log.Error("failed to disable webhook", "webhook_id", webhook.ID, "error", disableErr)
+		}
+	}
+
+	return err
+}
+
+func (s *Service) sendRequest(ctx context.Context, webhook models.Webhook, event models.RawEvent) (models.DeliveryAttempt, error) {
+	attempt := models.DeliveryAttempt{
+		EventID:   event.ID,
+		WebhookID: webhook.ID,
+	}
+
+	r := bytes.NewReader([]byte(event.Payload))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, r)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return attempt, &DeliveryError{Kind: ErrorKindPermanent, Err: fmt.Errorf("failed to create request: %w", err)}
+	}
+	if err := s.egressPolicy.CheckURL(req.URL); err != nil {
+		return attempt, &DeliveryError{Kind: ErrorKindPermanent, Err: err}
 	}
 
+	attempt.RequestedAt = time.Now()
 	req.Header.Set("Content-Type", "application/json")
-	if secret != "" {
-		req.Header.Set("X-Secret", secret)
+	if event.Sequence > 0 {
+		req.Header.Set(headerWebhookSequence, strconv.FormatInt(event.Sequence, 10))
 	}
+	setAuthHeaders(req, webhook, event.ID, event.Payload, attempt.RequestedAt)
 
 	resp, err := s.httpClient.Do(req)
+	attempt.Latency = time.Since(attempt.RequestedAt)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		kind := ErrorKindRetriable
+		if errors.Is(err, models.ErrEgressDenied) {
+			kind = ErrorKindPermanent
+		}
+		return attempt, &DeliveryError{Kind: kind, Err: fmt.Errorf("failed to send request: %w", err)}
 	}
 	defer func() {
 		_, _ = io.Copy(io.Discard, resp.Body)
@@ -107,9 +290,23 @@ func (s *Service) sendRequest(ctx context.Context, url, secret, payload string)
 		}
 	}()
 
+	attempt.StatusCode = resp.StatusCode
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRecordedResponseBody))
+	if err != nil {
+		s.log.Warn("failed to read response body", "event_id", event.ID, "error", err)
+	}
+	// Postgres TEXT accepts neither invalid UTF-8 nor NUL bytes.
+	attempt.ResponseBody = strings.ReplaceAll(strings.ToValidUTF8(string(body), ""), "\x00", "")
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("received non-2xx response: %d", resp.StatusCode)
+		deliveryErr := statusError(resp.StatusCode)
+		if deliveryErr.Kind == ErrorKindRetriable {
+			if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				deliveryErr.RetryAfter = delay
+			}
+		}
+		return attempt, deliveryErr
 	}
 
-	return nil
+	return attempt, nil
 }
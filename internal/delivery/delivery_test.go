@@ -2,15 +2,23 @@ package delivery
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hookify/internal/models"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
-func TestSendRequest_SetsSecretHeader_AndOK(t *testing.T) {
+func TestSendRequest_LegacySetsSecretHeader_AndOK(t *testing.T) {
 	secret := "s3cr3t"
 
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -23,11 +31,12 @@ func TestSendRequest_SetsSecretHeader_AndOK(t *testing.T) {
 	defer srv.Close()
 
 	s := &Service{
-		log:        slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
-		httpClient: &http.Client{Timeout: 2 * time.Second},
+		log:          slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
+		httpClient:   &http.Client{Timeout: 2 * time.Second},
+		egressPolicy: models.EgressPolicy{AllowPrivateNetworks: true},
 	}
 
-	if err := s.sendRequest(context.Background(), srv.URL, secret, `{}`); err != nil {
+	if _, err := s.sendRequest(context.Background(), models.Webhook{URL: srv.URL, Secret: secret, SignatureScheme: models.SignatureSchemeLegacy}, models.RawEvent{ID: 1, Payload: `{}`}); err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
 }
@@ -39,11 +48,903 @@ func TestSendRequest_Non2xxIsError(t *testing.T) {
 	defer srv.Close()
 
 	s := &Service{
-		log:        slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
-		httpClient: &http.Client{Timeout: 2 * time.Second},
+		log:          slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
+		httpClient:   &http.Client{Timeout: 2 * time.Second},
+		egressPolicy: models.EgressPolicy{AllowPrivateNetworks: true},
 	}
 
-	if err := s.sendRequest(context.Background(), srv.URL, "", `{}`); err == nil {
+	if _, err := s.sendRequest(context.Background(), models.Webhook{URL: srv.URL}, models.RawEvent{ID: 1, Payload: `{}`}); err == nil {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestSendRequest_LegacySendsPreviousSecretDuringGracePeriod(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Values("X-Secret")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := &Service{
+		log:          slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
+		httpClient:   &http.Client{Timeout: 2 * time.Second},
+		egressPolicy: models.EgressPolicy{AllowPrivateNetworks: true},
+	}
+
+	expiresAt := time.Now().Add(time.Minute)
+	webhook := models.Webhook{URL: srv.URL, Secret: "new", PreviousSecret: "old", PreviousSecretExpiresAt: &expiresAt, SignatureScheme: models.SignatureSchemeLegacy}
+	if _, err := s.sendRequest(context.Background(), webhook, models.RawEvent{ID: 1, Payload: `{}`}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(got) != 2 || got[0] != "new" || got[1] != "old" {
+		t.Fatalf("unexpected X-Secret values: %#v", got)
+	}
+}
+
+func TestSendRequest_StandardSignsPayload(t *testing.T) {
+	var (
+		header http.Header
+		body   string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := &Service{
+		log:          slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
+		httpClient:   &http.Client{Timeout: 2 * time.Second},
+		egressPolicy: models.EgressPolicy{AllowPrivateNetworks: true},
+	}
+
+	webhook := models.Webhook{URL: srv.URL, Secret: "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw", SignatureScheme: models.SignatureSchemeStandard}
+	if _, err := s.sendRequest(context.Background(), webhook, models.RawEvent{ID: 42, Payload: `{"a":1}`}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if header.Get("X-Secret") != "" {
+		t.Fatalf("expected no X-Secret header for standard scheme")
+	}
+	if header.Get("webhook-id") != "42" {
+		t.Fatalf("expected webhook-id=42, got %q", header.Get("webhook-id"))
+	}
+	want := sign(webhook.Secret, "42", header.Get("webhook-timestamp"), body)
+	if header.Get("webhook-signature") != want {
+		t.Fatalf("expected signature %q, got %q", want, header.Get("webhook-signature"))
+	}
+}
+
+func TestSign_StandardWebhooksTestVector(t *testing.T) {
+	got := sign("whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw", "msg_p5jXN8AQM9LWM0D4loKWxJek", "1614265330", `{"test": 2432232314}`)
+	want := "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSign_LegacyHexSecretUsesRawBytes(t *testing.T) {
+	secret := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("1.1614265330.{}"))
+	want := "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if got := sign(secret, "1", "1614265330", "{}"); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSetAuthHeaders_SignsWithEveryValidSecret(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Minute)
+	webhook := models.Webhook{Secret: "new", PreviousSecret: "old", PreviousSecretExpiresAt: &expiresAt, SignatureScheme: models.SignatureSchemeStandard}
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
+	setAuthHeaders(req, webhook, 1, `{}`, now)
+
+	signatures := strings.Fields(req.Header.Get("webhook-signature"))
+	if len(signatures) != 2 {
+		t.Fatalf("expected 2 signatures, got %#v", signatures)
+	}
+}
+
+type attemptRecorderMock struct {
+	attempts []models.DeliveryAttempt
+}
+
+func (m *attemptRecorderMock) SaveDeliveryAttempt(ctx context.Context, attempt models.DeliveryAttempt) error {
+	m.attempts = append(m.attempts, attempt)
+	return nil
+}
+
+func TestDeliver_RecordsAttempt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(strings.Repeat("x", maxRecordedResponseBody+100)))
+	}))
+	defer srv.Close()
+
+	recorder := &attemptRecorderMock{}
+	s := &Service{
+		log:             slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
+		attemptRecorder: recorder,
+		circuits:        &circuitStoreMock{},
+		circuitPolicy:   models.CircuitPolicy{FailureThreshold: 3, Cooldown: time.Minute},
+		httpClient:      &http.Client{Timeout: 2 * time.Second},
+		egressPolicy:    models.EgressPolicy{AllowPrivateNetworks: true},
+	}
+
+	err := s.deliver(context.Background(), models.Webhook{ID: 3, URL: srv.URL}, models.RawEvent{ID: 7, Payload: `{}`})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(recorder.attempts) != 1 {
+		t.Fatalf("expected 1 recorded attempt, got %d", len(recorder.attempts))
+	}
+
+	a := recorder.attempts[0]
+	if a.EventID != 7 || a.WebhookID != 3 {
+		t.Fatalf("unexpected ids: event=%d webhook=%d", a.EventID, a.WebhookID)
+	}
+	if a.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", a.StatusCode)
+	}
+	if len(a.ResponseBody) != maxRecordedResponseBody {
+		t.Fatalf("expected response body truncated to %d bytes, got %d", maxRecordedResponseBody, len(a.ResponseBody))
+	}
+	if a.Error == "" || a.RequestedAt.IsZero() {
+		t.Fatalf("expected error and request time to be recorded: %#v", a)
+	}
+}
+
+func TestDeliver_RecordsTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	recorder := &attemptRecorderMock{}
+	s := &Service{
+		log:             slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{})),
+		attemptRecorder: recorder,
+		circuits:        &circuitStoreMock{},
+		circuitPolicy:   models.CircuitPolicy{FailureThreshold: 3, Cooldown: time.Minute},
+		httpClient:      &http.Client{Timeout: 2 * time.Second},
+		egressPolicy:    models.EgressPolicy{AllowPrivateNetworks: true},
+	}
+
+	if err := s.deliver(context.Background(), models.Webhook{URL: url}, models.RawEvent{ID: 1, Payload: `{}`}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(recorder.attempts) != 1 || recorder.attempts[0].StatusCode != 0 || recorder.attempts[0].Error == "" {
+		t.Fatalf("expected transport error to be recorded, got %#v", recorder.attempts)
+	}
+}
+
+type webhookProviderMock struct {
+	webhook models.Webhook
+	err     error
+}
+
+func (m *webhookProviderMock) GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	return m.webhook, m.err
+}
+
+type webhookDisablerMock struct {
+	disabled map[int64]string
+}
+
+func (m *webhookDisablerMock) DisableWebhook(ctx context.Context, webhookID int64, reason string) error {
+	if m.disabled == nil {
+		m.disabled = make(map[int64]string)
+	}
+	m.disabled[webhookID] = reason
+	return nil
+}
+
+type circuitStoreMock struct {
+	circuit   models.Circuit
+	probe     bool
+	successes int
+	failures  int
+}
+
+func (m *circuitStoreMock) GetCircuit(ctx context.Context, webhookID int64) (models.Circuit, error) {
+	if m.circuit.State == "" {
+		return models.Circuit{WebhookID: webhookID, State: models.CircuitStateClosed}, nil
+	}
+	return m.circuit, nil
+}
+
+func (m *circuitStoreMock) TryHalfOpenCircuit(ctx context.Context, webhookID int64, probeTimeout time.Duration) (bool, error) {
+	if !m.probe {
+		return false, nil
+	}
+	m.probe = false
+	m.circuit.State = models.CircuitStateHalfOpen
+	return true, nil
+}
+
+func (m *circuitStoreMock) RecordCircuitSuccess(ctx context.Context, webhookID int64) error {
+	m.successes++
+	m.circuit = models.Circuit{WebhookID: webhookID, State: models.CircuitStateClosed}
+	return nil
+}
+
+func (m *circuitStoreMock) RecordCircuitFailure(ctx context.Context, webhookID int64, threshold int, cooldown time.Duration) (models.Circuit, error) {
+	m.failures++
+	m.circuit.WebhookID = webhookID
+	m.circuit.ConsecutiveFailures++
+	if m.circuit.State == models.CircuitStateHalfOpen || m.circuit.ConsecutiveFailures >= threshold {
+		openUntil := time.Now().Add(cooldown)
+		m.circuit.State = models.CircuitStateOpen
+		m.circuit.OpenUntil = &openUntil
+	} else if m.circuit.State == "" {
+		m.circuit.State = models.CircuitStateClosed
+	}
+	return m.circuit, nil
+}
+
+type eventStatusUpdaterMock struct {
+	statuses map[int64]models.EventStatus
+}
+
+func (m *eventStatusUpdaterMock) UpdateEventStatus(ctx context.Context, eventID int64, status models.EventStatus) error {
+	if m.statuses == nil {
+		m.statuses = make(map[int64]models.EventStatus)
+	}
+	m.statuses[eventID] = status
+	return nil
+}
+
+type outboxRepoMock struct {
+	mu        sync.Mutex
+	due       []models.OutboxEntry
+	claimedBy []string
+	saved     []models.OutboxEntry
+	updated   map[int64]models.OutboxEntry
+	deleted   []int64
+	blocked   map[int64]bool
+}
+
+func (m *outboxRepoMock) ClaimDueOutboxEntries(ctx context.Context, workerID string, limit int, lease time.Duration) ([]models.OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.claimedBy = append(m.claimedBy, workerID)
+	due := m.due
+	m.due = nil
+	return due, nil
+}
+
+func (m *outboxRepoMock) UpdateOutboxEntry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time) error {
+	if m.updated == nil {
+		m.updated = make(map[int64]models.OutboxEntry)
+	}
+	m.updated[id] = models.OutboxEntry{ID: id, Attempts: attempts, NextAttemptAt: nextAttemptAt}
+	return nil
+}
+
+func (m *outboxRepoMock) DeleteOutboxEntry(ctx context.Context, id int64) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func (m *outboxRepoMock) SaveOutboxEntry(ctx context.Context, eventID int64, webhookID int64, payload string, attempts int, nextAttemptAt time.Time, jobType models.OutboxType) (int64, error) {
+	m.saved = append(m.saved, models.OutboxEntry{EventID: eventID, WebhookID: webhookID, Payload: payload, Attempts: attempts, NextAttemptAt: nextAttemptAt, Type: jobType})
+	return int64(len(m.saved)), nil
+}
+
+// deadLetterRepoMock mirrors the storage transaction: dead-lettering an outbox
+// entry deletes it and fails its event.
+type deadLetterRepoMock struct {
+	outbox   *outboxRepoMock
+	statuses *eventStatusUpdaterMock
+	saved    []models.DeadLetter
+}
+
+func (m *deadLetterRepoMock) SaveDeadLetter(ctx context.Context, deadLetter models.DeadLetter) (int64, error) {
+	m.saved = append(m.saved, deadLetter)
+	return int64(len(m.saved)), nil
+}
+
+func (m *deadLetterRepoMock) DeadLetterOutboxEntry(ctx context.Context, entryID int64, deadLetter models.DeadLetter) error {
+	m.saved = append(m.saved, deadLetter)
+	_ = m.outbox.DeleteOutboxEntry(ctx, entryID)
+	return m.statuses.UpdateEventStatus(ctx, deadLetter.EventID, models.EventStatusFailed)
+}
+
+func (m *outboxRepoMock) IsHeadOfLine(ctx context.Context, webhookID int64, eventID int64) (bool, error) {
+	return !m.blocked[eventID], nil
+}
+
+func newTestService(webhook models.Webhook, outbox *outboxRepoMock, statuses *eventStatusUpdaterMock) *Service {
+	return &Service{
+		log:                slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		webhookProvider:    &webhookProviderMock{webhook: webhook},
+		webhookDisabler:    &webhookDisablerMock{},
+		eventStatusUpdater: statuses,
+		outboxRepo:         outbox,
+		attemptRecorder:    &attemptRecorderMock{},
+		circuits:           &circuitStoreMock{},
+		deadLetters:        &deadLetterRepoMock{outbox: outbox, statuses: statuses},
+		retryPolicy:        models.RetryPolicy{InitialInterval: time.Second, Multiplier: 2, MaxInterval: time.Minute, MaxAge: 24 * time.Hour},
+		circuitPolicy:      models.CircuitPolicy{FailureThreshold: 3, Cooldown: 30 * time.Second, DisableThreshold: 10},
+		httpClient:         &http.Client{Timeout: 2 * time.Second},
+		egressPolicy:       models.EgressPolicy{AllowPrivateNetworks: true},
+	}
+}
+
+func failingServer(t *testing.T, code int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProcessOutbox_ReschedulesWithPolicyBackoff(t *testing.T) {
+	srv := failingServer(t, http.StatusServiceUnavailable)
+	outbox := &outboxRepoMock{due: []models.OutboxEntry{
+		{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, Payload: `{}`, Attempts: 2, CreatedAt: time.Now()},
+	}}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL}, outbox, &eventStatusUpdaterMock{})
+
+	before := time.Now()
+	if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	u, ok := outbox.updated[1]
+	if !ok {
+		t.Fatalf("expected entry to be rescheduled")
+	}
+	if u.Attempts != 3 {
+		t.Fatalf("expected attempts=3, got %d", u.Attempts)
+	}
+	if delay := u.NextAttemptAt.Sub(before); delay < 4*time.Second || delay > 5*time.Second {
+		t.Fatalf("expected ~4s backoff for third failure, got %v", delay)
+	}
+}
+
+func TestProcessOutbox_WebhookPolicyOverridesDefault(t *testing.T) {
+	srv := failingServer(t, http.StatusServiceUnavailable)
+	outbox := &outboxRepoMock{due: []models.OutboxEntry{
+		{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, Payload: `{}`, Attempts: 2, CreatedAt: time.Now()},
+	}}
+	statuses := &eventStatusUpdaterMock{}
+	override := models.RetryPolicy{InitialInterval: time.Second, Multiplier: 1, MaxInterval: time.Second, MaxAttempts: 3}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL, RetryPolicy: &override}, outbox, statuses)
+
+	if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(outbox.deleted) != 1 || outbox.deleted[0] != 1 {
+		t.Fatalf("expected exhausted entry to be deleted, got %#v", outbox.deleted)
+	}
+	if statuses.statuses[10] != models.EventStatusFailed {
+		t.Fatalf("expected event to be failed, got %q", statuses.statuses[10])
+	}
+
+	deadLetters := s.deadLetters.(*deadLetterRepoMock).saved
+	if len(deadLetters) != 1 {
+		t.Fatalf("expected one dead letter, got %#v", deadLetters)
+	}
+	dl := deadLetters[0]
+	if dl.Source != models.DeadLetterSourceOutbox || dl.Reason != models.DeadLetterReasonRetriesExhausted || dl.Attempts != 3 {
+		t.Fatalf("unexpected dead letter %#v", dl)
+	}
+	if dl.EventID != 10 || dl.WebhookID != 2 || dl.Type != models.OutboxTypeDelivery || dl.Payload != `{}` || dl.LastError == "" {
+		t.Fatalf("expected dead letter to keep the entry, got %#v", dl)
+	}
+}
+
+func TestStatusError_Classification(t *testing.T) {
+	tests := []struct {
+		code int
+		want ErrorKind
+	}{
+		{http.StatusInternalServerError, ErrorKindRetriable},
+		{http.StatusBadGateway, ErrorKindRetriable},
+		{http.StatusRequestTimeout, ErrorKindRetriable},
+		{http.StatusTooManyRequests, ErrorKindRetriable},
+		{http.StatusBadRequest, ErrorKindPermanent},
+		{http.StatusUnauthorized, ErrorKindPermanent},
+		{http.StatusNotFound, ErrorKindPermanent},
+		{http.StatusGone, ErrorKindGone},
+	}
+	for _, tt := range tests {
+		err := statusError(tt.code)
+		if err.Kind != tt.want {
+			t.Errorf("status %d: expected %s, got %s", tt.code, tt.want, err.Kind)
+		}
+		if err.StatusCode != tt.code {
+			t.Errorf("status %d: expected status code on error, got %d", tt.code, err.StatusCode)
+		}
+	}
+}
+
+func TestSendRequest_TransportErrorIsRetriable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+
+	s := newTestService(models.Webhook{}, &outboxRepoMock{}, &eventStatusUpdaterMock{})
+	_, err := s.sendRequest(context.Background(), models.Webhook{ID: 1, URL: srv.URL}, models.RawEvent{ID: 1, Payload: `{}`})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if kind := errorKind(err); kind != ErrorKindRetriable {
+		t.Fatalf("expected retriable, got %s", kind)
+	}
+}
+
+func TestHandleEvent_PermanentErrorFailsImmediately(t *testing.T) {
+	srv := failingServer(t, http.StatusNotFound)
+	outbox := &outboxRepoMock{}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL}, outbox, statuses)
+
+	if err := s.HandleEvent(context.Background(), models.RawEvent{ID: 10, WebhookID: 2, Payload: `{}`}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(outbox.saved) != 0 {
+		t.Fatalf("expected no retry to be queued, got %#v", outbox.saved)
+	}
+	if statuses.statuses[10] != models.EventStatusFailed {
+		t.Fatalf("expected event to be failed, got %q", statuses.statuses[10])
+	}
+	deadLetters := s.deadLetters.(*deadLetterRepoMock).saved
+	if len(deadLetters) != 1 || deadLetters[0].Reason != models.DeadLetterReasonPermanentFailure {
+		t.Fatalf("expected a permanent failure dead letter, got %#v", deadLetters)
+	}
+}
+
+func TestHandleEvent_OrderedQueuesBehindEarlierEvents(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	outbox := &outboxRepoMock{blocked: map[int64]bool{11: true}}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL, Ordered: true}, outbox, statuses)
+
+	if err := s.HandleEvent(context.Background(), models.RawEvent{ID: 11, WebhookID: 2, Sequence: 5, Payload: `{}`}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if called {
+		t.Fatalf("expected delivery to wait for earlier events")
+	}
+	if len(outbox.saved) != 1 || outbox.saved[0].EventID != 11 || outbox.saved[0].Attempts != 0 || outbox.saved[0].Type != models.OutboxTypeDelivery {
+		t.Fatalf("expected delivery queued in the outbox, got %#v", outbox.saved)
+	}
+	if _, ok := statuses.statuses[11]; ok {
+		t.Fatalf("expected event to stay pending, got %q", statuses.statuses[11])
+	}
+}
+
+func TestHandleEvent_SendsSequenceHeader(t *testing.T) {
+	var sequence string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sequence = r.Header.Get("webhook-sequence")
+	}))
+	defer srv.Close()
+
+	outbox := &outboxRepoMock{}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL, Ordered: true}, outbox, statuses)
+
+	if err := s.HandleEvent(context.Background(), models.RawEvent{ID: 10, WebhookID: 2, Sequence: 4, Payload: `{}`}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if sequence != "4" {
+		t.Fatalf("expected webhook-sequence=4, got %q", sequence)
+	}
+	if statuses.statuses[10] != models.EventStatusDelivered {
+		t.Fatalf("expected head of line to be delivered, got %q", statuses.statuses[10])
+	}
+}
+
+func TestHandleEvent_UnsubscribedEventIsFiltered(t *testing.T) {
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+	}))
+	defer srv.Close()
+
+	outbox := &outboxRepoMock{}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL, EventTypes: []string{"invoice.*"}}, outbox, statuses)
+
+	events := []models.RawEvent{
+		{ID: 10, WebhookID: 2, EventType: "invoice.paid", Payload: `{"n":10}`},
+		{ID: 11, WebhookID: 2, EventType: "customer.created", Payload: `{"n":11}`},
+		{ID: 12, WebhookID: 2, Payload: `{"n":12}`},
+	}
+	for _, event := range events {
+		if err := s.HandleEvent(context.Background(), event); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+
+	if len(received) != 1 || received[0] != `{"n":10}` {
+		t.Fatalf("expected only the subscribed event to be delivered, got %v", received)
+	}
+	if statuses.statuses[10] != models.EventStatusDelivered || statuses.statuses[11] != models.EventStatusFiltered || statuses.statuses[12] != models.EventStatusFiltered {
+		t.Fatalf("unexpected statuses %v", statuses.statuses)
+	}
+}
+
+func TestProcessOutbox_UnsubscribedDeliveryIsFiltered(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	outbox := &outboxRepoMock{due: []models.OutboxEntry{
+		{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, EventType: "customer.created", Payload: `{}`, Attempts: 1, CreatedAt: time.Now()},
+	}}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL, EventTypes: []string{"invoice.paid"}}, outbox, statuses)
+
+	if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if called {
+		t.Fatalf("expected endpoint not to be contacted")
+	}
+	if statuses.statuses[10] != models.EventStatusFiltered {
+		t.Fatalf("expected event to be filtered, got %q", statuses.statuses[10])
+	}
+	if len(outbox.deleted) != 1 || len(outbox.updated) != 0 {
+		t.Fatalf("expected entry to be removed, deleted=%v updated=%v", outbox.deleted, outbox.updated)
+	}
+}
+
+func TestProcessOutbox_GoneDisablesWebhook(t *testing.T) {
+	srv := failingServer(t, http.StatusGone)
+	outbox := &outboxRepoMock{due: []models.OutboxEntry{
+		{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, Payload: `{}`, Attempts: 1, CreatedAt: time.Now()},
+	}}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL}, outbox, statuses)
+	disabler := s.webhookDisabler.(*webhookDisablerMock)
+
+	if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, ok := disabler.disabled[2]; !ok {
+		t.Fatalf("expected webhook to be disabled")
+	}
+	if len(outbox.deleted) != 1 || len(outbox.updated) != 0 {
+		t.Fatalf("expected entry to be dropped, deleted=%v updated=%v", outbox.deleted, outbox.updated)
+	}
+	if statuses.statuses[10] != models.EventStatusFailed {
+		t.Fatalf("expected event to be failed, got %q", statuses.statuses[10])
+	}
+}
+
+func TestProcessOutbox_DisabledWebhookIsNotContacted(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	outbox := &outboxRepoMock{due: []models.OutboxEntry{
+		{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, Payload: `{}`, CreatedAt: time.Now()},
+	}}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL, Status: models.WebhookStatusDisabled}, outbox, statuses)
+
+	if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if called {
+		t.Fatalf("expected disabled webhook not to be contacted")
+	}
+	if len(outbox.deleted) != 1 || statuses.statuses[10] != models.EventStatusFailed {
+		t.Fatalf("expected entry dropped and event failed, deleted=%v status=%q", outbox.deleted, statuses.statuses[10])
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestProcessOutbox_HonorsRetryAfterCappedByMaxInterval(t *testing.T) {
+	for _, tt := range []struct {
+		header string
+		want   time.Duration
+	}{
+		{"30", 30 * time.Second},
+		{"7200", time.Minute},
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", tt.header)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		outbox := &outboxRepoMock{due: []models.OutboxEntry{
+			{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, Payload: `{}`, Attempts: 1, CreatedAt: time.Now()},
+		}}
+		s := newTestService(models.Webhook{ID: 2, URL: srv.URL}, outbox, &eventStatusUpdaterMock{})
+
+		before := time.Now()
+		if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		srv.Close()
+
+		u, ok := outbox.updated[1]
+		if !ok {
+			t.Fatalf("Retry-After %s: expected entry to be rescheduled", tt.header)
+		}
+		if delay := u.NextAttemptAt.Sub(before); delay < tt.want || delay > tt.want+time.Second {
+			t.Fatalf("Retry-After %s: expected delay ~%v, got %v", tt.header, tt.want, delay)
+		}
+	}
+}
+
+func TestDeliver_OpensCircuitAndDisablesWebhook(t *testing.T) {
+	srv := failingServer(t, http.StatusServiceUnavailable)
+	s := newTestService(models.Webhook{}, &outboxRepoMock{}, &eventStatusUpdaterMock{})
+	circuits := s.circuits.(*circuitStoreMock)
+	disabler := s.webhookDisabler.(*webhookDisablerMock)
+	webhook := models.Webhook{ID: 2, URL: srv.URL}
+
+	for i := 0; i < 3; i++ {
+		if err := s.deliver(context.Background(), webhook, models.RawEvent{ID: 10, Payload: `{}`}); err == nil || errors.Is(err, errCircuitOpen) {
+			t.Fatalf("attempt %d: expected delivery failure, got %v", i+1, err)
+		}
+	}
+	if circuits.circuit.State != models.CircuitStateOpen {
+		t.Fatalf("expected circuit to open after 3 failures, got %q", circuits.circuit.State)
+	}
+
+	err := s.deliver(context.Background(), webhook, models.RawEvent{ID: 10, Payload: `{}`})
+	if !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected delivery to be held, got %v", err)
+	}
+	var deliveryErr *DeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.RetryAfter <= 0 || deliveryErr.RetryAfter > 30*time.Second {
+		t.Fatalf("expected hold until the cooldown ends, got %#v", deliveryErr)
+	}
+	if circuits.failures != 3 {
+		t.Fatalf("expected held delivery not to count as a failure, got %d failures", circuits.failures)
+	}
+
+	circuits.circuit.ConsecutiveFailures = 9
+	circuits.probe = true
+	if err := s.deliver(context.Background(), webhook, models.RawEvent{ID: 10, Payload: `{}`}); err == nil || errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected probe to be sent and fail, got %v", err)
+	}
+	if circuits.circuit.State != models.CircuitStateOpen {
+		t.Fatalf("expected failed probe to reopen circuit, got %q", circuits.circuit.State)
+	}
+	if _, ok := disabler.disabled[2]; !ok {
+		t.Fatalf("expected webhook to be disabled after 10 consecutive failures")
+	}
+}
+
+func TestDeliver_SuccessfulProbeClosesCircuit(t *testing.T) {
+	srv := failingServer(t, http.StatusOK)
+	s := newTestService(models.Webhook{}, &outboxRepoMock{}, &eventStatusUpdaterMock{})
+	circuits := s.circuits.(*circuitStoreMock)
+	openUntil := time.Now().Add(-time.Second)
+	circuits.circuit = models.Circuit{WebhookID: 2, State: models.CircuitStateOpen, ConsecutiveFailures: 5, OpenUntil: &openUntil}
+	circuits.probe = true
+
+	if err := s.deliver(context.Background(), models.Webhook{ID: 2, URL: srv.URL}, models.RawEvent{ID: 10, Payload: `{}`}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if circuits.circuit.State != models.CircuitStateClosed || circuits.circuit.ConsecutiveFailures != 0 {
+		t.Fatalf("expected circuit to close, got %#v", circuits.circuit)
+	}
+}
+
+func TestProcessOutbox_HeldDeliveryKeepsAttempts(t *testing.T) {
+	outbox := &outboxRepoMock{due: []models.OutboxEntry{
+		{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, Payload: `{}`, Attempts: 4, CreatedAt: time.Now()},
+	}}
+	s := newTestService(models.Webhook{ID: 2, URL: "http://127.0.0.1:1"}, outbox, &eventStatusUpdaterMock{})
+	openUntil := time.Now().Add(20 * time.Second)
+	s.circuits.(*circuitStoreMock).circuit = models.Circuit{WebhookID: 2, State: models.CircuitStateOpen, ConsecutiveFailures: 5, OpenUntil: &openUntil}
+
+	if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	u, ok := outbox.updated[1]
+	if !ok {
+		t.Fatalf("expected held entry to be rescheduled")
+	}
+	if u.Attempts != 4 {
+		t.Fatalf("expected attempts to stay at 4, got %d", u.Attempts)
+	}
+	if delay := time.Until(u.NextAttemptAt); delay < 15*time.Second || delay > 20*time.Second {
+		t.Fatalf("expected entry held until the circuit may be probed, got %v", delay)
+	}
+}
+
+func TestProcessOutbox_PausedDeliveryStaysDue(t *testing.T) {
+	outbox := &outboxRepoMock{due: []models.OutboxEntry{
+		{ID: 1, Type: models.OutboxTypeDelivery, EventID: 10, WebhookID: 2, Payload: `{}`, Attempts: 2, CreatedAt: time.Now()},
+	}}
+	s := newTestService(models.Webhook{ID: 2, URL: "http://127.0.0.1:1", Status: models.WebhookStatusPaused}, outbox, &eventStatusUpdaterMock{})
+
+	if _, err := s.processOutbox(context.Background(), "worker-1", time.Minute); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	u, ok := outbox.updated[1]
+	if !ok {
+		t.Fatalf("expected held entry to be rescheduled")
+	}
+	if u.Attempts != 2 || time.Until(u.NextAttemptAt) > 0 {
+		t.Fatalf("expected entry to stay due with its attempts, got %+v", u)
+	}
+}
+
+func TestHandleEvent_PausedWebhookHoldsDelivery(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	outbox := &outboxRepoMock{}
+	statuses := &eventStatusUpdaterMock{}
+	s := newTestService(models.Webhook{ID: 2, URL: srv.URL, Status: models.WebhookStatusPaused}, outbox, statuses)
+
+	if err := s.HandleEvent(context.Background(), models.RawEvent{ID: 10, WebhookID: 2, Payload: `{}`}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if called {
+		t.Fatalf("expected paused webhook not to be contacted")
+	}
+	if len(outbox.saved) != 1 || outbox.saved[0].Attempts != 0 || outbox.saved[0].Type != models.OutboxTypeDelivery {
+		t.Fatalf("expected delivery to be held in the outbox, got %#v", outbox.saved)
+	}
+	if _, ok := statuses.statuses[10]; ok {
+		t.Fatalf("expected event to stay pending, got %q", statuses.statuses[10])
+	}
+	if s.circuits.(*circuitStoreMock).failures != 0 {
+		t.Fatalf("expected held delivery not to count against the circuit")
+	}
+}
+
+func TestHTTPClient_RefusesPrivateAddressAfterResolution(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient(models.EgressPolicy{}, 2*time.Second)
+	req, err := http.NewRequest(http.MethodPost, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	_, err = client.Do(req)
+	if !errors.Is(err, models.ErrEgressDenied) {
+		t.Fatalf("expected ErrEgressDenied, got %v", err)
+	}
+	if called {
+		t.Fatalf("expected no request to reach the server")
+	}
+}
+
+func TestHTTPClient_ChecksRedirectTargets(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer target.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace(target.URL, "http://", "ftp://", 1), http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient(models.EgressPolicy{AllowedSchemes: []string{"http"}, AllowPrivateNetworks: true}, 2*time.Second)
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, models.ErrEgressDenied) {
+		t.Fatalf("expected ErrEgressDenied, got %v", err)
+	}
+}
+
+func TestSendRequest_EgressDeniedIsPermanent(t *testing.T) {
+	s := newTestService(models.Webhook{}, &outboxRepoMock{}, &eventStatusUpdaterMock{})
+	s.egressPolicy = models.EgressPolicy{}
+
+	_, err := s.sendRequest(context.Background(), models.Webhook{ID: 1, URL: "http://169.254.169.254/latest/meta-data"}, models.RawEvent{ID: 1, Payload: `{}`})
+	if !errors.Is(err, models.ErrEgressDenied) {
+		t.Fatalf("expected ErrEgressDenied, got %v", err)
+	}
+	if kind := errorKind(err); kind != ErrorKindPermanent {
+		t.Fatalf("expected permanent, got %s", kind)
+	}
+}
+
+func TestRunOutboxWorker_RunsParallelWorkers(t *testing.T) {
+	outbox := &outboxRepoMock{}
+	s := newTestService(models.Webhook{}, outbox, &eventStatusUpdaterMock{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	s.RunOutboxWorker(ctx, 10*time.Millisecond, 3, time.Minute, nil)
+
+	outbox.mu.Lock()
+	defer outbox.mu.Unlock()
+	workers := make(map[string]bool)
+	for _, id := range outbox.claimedBy {
+		workers[id] = true
+	}
+	if len(workers) != 3 {
+		t.Fatalf("expected 3 distinct workers to claim entries, got %v", workers)
+	}
+}
+
+func TestRunOutboxWorker_DrainsOnWakeup(t *testing.T) {
+	outbox := &outboxRepoMock{}
+	s := newTestService(models.Webhook{}, outbox, &eventStatusUpdaterMock{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wakeups := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		s.RunOutboxWorker(ctx, time.Hour, 2, time.Minute, wakeups)
+		close(done)
+	}()
+
+	wakeups <- struct{}{}
+	deadline := time.After(time.Second)
+	for {
+		outbox.mu.Lock()
+		claims := len(outbox.claimedBy)
+		outbox.mu.Unlock()
+		if claims >= 2 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected both workers to drain after a wakeup, got %d claims", claims)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+}
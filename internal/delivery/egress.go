@@ -0,0 +1,65 @@
+package delivery
+
+import (
+	"fmt"
+	"hookify/internal/models"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// maxRedirects matches the net/http default.
+const maxRedirects = 10
+
+// newHTTPClient returns the client used for deliveries. Every connection,
+// including those made while following redirects, is checked against the
+// egress policy once the host has been resolved, so a name that resolves to
+// an internal address is refused however it got there. Proxies from the
+// environment are ignored as they would hide the dialed address.
+func newHTTPClient(policy models.EgressPolicy, timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkDialAddress(policy, address)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return policy.CheckURL(req.URL)
+		},
+	}
+}
+
+func checkDialAddress(policy models.EgressPolicy, address string) error {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrEgressDenied, err)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: unresolved address %q", models.ErrEgressDenied, host)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return fmt.Errorf("%w: invalid port %q", models.ErrEgressDenied, portStr)
+	}
+
+	if err := policy.CheckPort(port); err != nil {
+		return err
+	}
+	return policy.CheckIP(addr)
+}
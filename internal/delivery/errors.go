@@ -0,0 +1,109 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errWebhookDisabled = errors.New("webhook is disabled")
+	errWebhookPaused   = errors.New("webhook is paused")
+)
+
+// ErrorKind tells how a failed delivery should be handled.
+type ErrorKind int
+
+const (
+	// ErrorKindRetriable failures are rescheduled according to the retry policy.
+	ErrorKindRetriable ErrorKind = iota
+	// ErrorKindPermanent failures will not succeed on retry; the event fails immediately.
+	ErrorKindPermanent
+	// ErrorKindGone means the endpoint no longer exists; the event fails and the webhook is disabled.
+	ErrorKindGone
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindPermanent:
+		return "permanent"
+	case ErrorKindGone:
+		return "gone"
+	default:
+		return "retriable"
+	}
+}
+
+// DeliveryError is returned by sendRequest when a delivery attempt fails.
+// StatusCode is zero when no response was received. RetryAfter is the delay
+// the endpoint asked for via the Retry-After header, or zero.
+type DeliveryError struct {
+	Kind       ErrorKind
+	StatusCode int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *DeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// statusError classifies a non-2xx response.
+func statusError(code int) *DeliveryError {
+	kind := ErrorKindRetriable
+	switch {
+	case code == http.StatusGone:
+		kind = ErrorKindGone
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests:
+	case code >= 400 && code < 500:
+		kind = ErrorKindPermanent
+	}
+	return &DeliveryError{
+		Kind:       kind,
+		StatusCode: code,
+		Err:        fmt.Errorf("received non-2xx response: %d", code),
+	}
+}
+
+// errorKind reports the kind of a delivery failure. Errors that are not a
+// DeliveryError, such as storage failures, are treated as retriable.
+func errorKind(err error) ErrorKind {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Kind
+	}
+	return ErrorKindRetriable
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds
+// or as an HTTP-date. It reports false for missing or malformed values.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(at.Sub(now), 0), true
+}
+
+// isHeld reports whether a delivery was not attempted but held back for later,
+// because the webhook is paused or its circuit is open.
+func isHeld(err error) bool {
+	return errors.Is(err, errWebhookPaused) || errors.Is(err, errCircuitOpen)
+}
@@ -4,73 +4,154 @@ import (
 	"context"
 	"fmt"
 	"hookify/internal/models"
+	"os"
+	"sync"
 	"time"
 )
 
-func (s *Service) RunOutboxWorker(ctx context.Context, interval time.Duration) {
+// outboxBatchSize is how many entries a worker claims per poll.
+const outboxBatchSize = 10
+
+// RunOutboxWorker processes the outbox with workers parallel workers until ctx
+// is done. Every worker drains the outbox whenever wakeups fires and, as a
+// fallback for scheduled retries and missed notifications, every interval; a
+// nil wakeups channel leaves only the polling. Each worker claims its own
+// batch for lease, so workers in this and other processes never handle the
+// same entry at once; the lease must outlast processing a whole batch.
+func (s *Service) RunOutboxWorker(ctx context.Context, interval time.Duration, workers int, lease time.Duration, wakeups <-chan struct{}) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "hookify"
+	}
+
+	var wg sync.WaitGroup
+	workerWakeups := make([]chan struct{}, workers)
+	for i := range workers {
+		workerID := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), i)
+		workerWakeups[i] = make(chan struct{}, 1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runOutboxLoop(ctx, workerID, interval, lease, workerWakeups[i])
+		}()
+	}
+
+	// Fan every wakeup out to all workers so a burst is drained in parallel.
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-wakeups:
+			for _, ch := range workerWakeups {
+				select {
+				case ch <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+	wg.Wait()
+
+	s.log.Info("outbox worker stopped")
+}
+
+func (s *Service) runOutboxLoop(ctx context.Context, workerID string, interval time.Duration, lease time.Duration, wakeups <-chan struct{}) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			s.log.Info("outbox worker stopped")
 			return
 		case <-ticker.C:
-			if err := s.processOutbox(ctx); err != nil {
-				s.log.Error("failed to process outbox", "error", err)
-			}
+		case <-wakeups:
+		}
+
+		if err := s.drainOutbox(ctx, workerID, lease); err != nil {
+			s.log.Error("failed to process outbox", "worker_id", workerID, "error", err)
 		}
 	}
 }
 
-func (s *Service) processOutbox(ctx context.Context) error {
-	entries, err := s.outboxRepo.GetDueOutboxEntries(ctx, 10)
+// drainOutbox processes batches until the outbox has no more due entries.
+func (s *Service) drainOutbox(ctx context.Context, workerID string, lease time.Duration) error {
+	for ctx.Err() == nil {
+		claimed, err := s.processOutbox(ctx, workerID, lease)
+		if err != nil {
+			return err
+		}
+		if claimed < outboxBatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// processOutbox claims and processes one batch, returning how many entries it claimed.
+func (s *Service) processOutbox(ctx context.Context, workerID string, lease time.Duration) (int, error) {
+	entries, err := s.outboxRepo.ClaimDueOutboxEntries(ctx, workerID, outboxBatchSize, lease)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	for _, entry := range entries {
 		var processErr error
+		policy := s.retryPolicy
 
 		switch entry.Type {
 		case models.OutboxTypePublish:
-			rawEvent := models.RawEvent{
-				ID:        entry.EventID,
-				WebhookID: entry.WebhookID,
-				Payload:   entry.Payload,
-			}
-			processErr = s.eventPublisher.PublishEvent(ctx, rawEvent)
+			processErr = s.eventPublisher.PublishEvent(ctx, entryEvent(entry))
 		case models.OutboxTypeDelivery:
 			webhook, err := s.webhookProvider.GetWebhook(ctx, entry.WebhookID)
 			if err != nil {
 				processErr = err
 			} else {
-				processErr = s.sendRequest(ctx, webhook.URL, webhook.Secret, entry.Payload)
-				if processErr == nil {
-					if err := s.eventStatusUpdater.UpdateEventStatus(ctx, entry.EventID, models.EventStatusDelivered); err != nil {
-						processErr = fmt.Errorf("failed to update event status: %w", err)
+				policy = s.policyFor(webhook)
+				if !webhook.SubscribesTo(entry.EventType) {
+					// The subscription changed after the delivery was queued.
+					processErr = s.filterEvent(ctx, entryEvent(entry))
+				} else {
+					processErr = s.deliver(ctx, webhook, entryEvent(entry))
+					if processErr == nil {
+						if err := s.eventStatusUpdater.UpdateEventStatus(ctx, entry.EventID, models.EventStatusDelivered); err != nil {
+							processErr = fmt.Errorf("failed to update event status: %w", err)
+						}
 					}
 				}
 			}
 		}
 
 		if processErr != nil {
-			s.log.Error("failed to process outbox entry", "id", entry.ID, "type", entry.Type, "error", processErr)
-
-			if time.Since(entry.CreatedAt) > 24*time.Hour {
-				s.log.Error("outbox entry exceeded 24h deadline, dropping", "id", entry.ID)
-				if err := s.eventStatusUpdater.UpdateEventStatus(ctx, entry.EventID, models.EventStatusFailed); err != nil {
-					s.log.Error("failed to mark event as failed", "event_id", entry.EventID, "error", err)
+			if isHeld(processErr) {
+				// Holding a delivery is not a failed attempt, but it still ages.
+				if policy.Exhausted(entry.Attempts, time.Since(entry.CreatedAt)) {
+					s.log.Error("outbox entry expired while held, dropping", "id", entry.ID, "reason", processErr)
+					s.dropEntry(ctx, entry, models.DeadLetterReasonRetriesExhausted, processErr)
+					continue
 				}
-				if err := s.outboxRepo.DeleteOutboxEntry(ctx, entry.ID); err != nil {
-					s.log.Error("failed to delete expired outbox entry", "id", entry.ID, "error", err)
+				if err := s.outboxRepo.UpdateOutboxEntry(ctx, entry.ID, entry.Attempts, time.Now().Add(retryDelay(policy, entry.Attempts, processErr))); err != nil {
+					s.log.Error("failed to update outbox entry", "id", entry.ID, "error", err)
 				}
 				continue
 			}
 
+			s.log.Error("failed to process outbox entry", "id", entry.ID, "type", entry.Type, "error", processErr)
+
+			if kind := errorKind(processErr); kind != ErrorKindRetriable {
+				s.log.Error("outbox entry failed permanently, dropping", "id", entry.ID, "kind", kind)
+				s.dropEntry(ctx, entry, models.DeadLetterReasonPermanentFailure, processErr)
+				continue
+			}
+
 			nextAttempts := entry.Attempts + 1
-			nextAttemptAt := time.Now().Add(time.Duration(nextAttempts) * 5 * time.Second)
+			if policy.Exhausted(nextAttempts, time.Since(entry.CreatedAt)) {
+				s.log.Error("outbox entry exhausted its retry policy, dropping", "id", entry.ID, "attempts", nextAttempts)
+				entry.Attempts = nextAttempts
+				s.dropEntry(ctx, entry, models.DeadLetterReasonRetriesExhausted, processErr)
+				continue
+			}
+
+			nextAttemptAt := time.Now().Add(retryDelay(policy, nextAttempts, processErr))
 
 			if updateErr := s.outboxRepo.UpdateOutboxEntry(ctx, entry.ID, nextAttempts, nextAttemptAt); updateErr != nil {
 				s.log.Error("failed to update outbox entry", "id", entry.ID, "error", updateErr)
@@ -83,5 +164,33 @@ func (s *Service) processOutbox(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return len(entries), nil
+}
+
+func entryEvent(entry models.OutboxEntry) models.RawEvent {
+	return models.RawEvent{
+		ID:        entry.EventID,
+		WebhookID: entry.WebhookID,
+		EventType: entry.EventType,
+		Sequence:  entry.Sequence,
+		Payload:   entry.Payload,
+	}
+}
+
+// dropEntry gives up on an outbox entry, moving it to the dead letters and
+// marking its event failed.
+func (s *Service) dropEntry(ctx context.Context, entry models.OutboxEntry, reason string, processErr error) {
+	deadLetter := models.DeadLetter{
+		Source:    models.DeadLetterSourceOutbox,
+		EventID:   entry.EventID,
+		WebhookID: entry.WebhookID,
+		Type:      entry.Type,
+		Payload:   entry.Payload,
+		Reason:    reason,
+		LastError: processErr.Error(),
+		Attempts:  entry.Attempts,
+	}
+	if err := s.deadLetters.DeadLetterOutboxEntry(ctx, entry.ID, deadLetter); err != nil {
+		s.log.Error("failed to dead-letter outbox entry", "id", entry.ID, "error", err)
+	}
 }
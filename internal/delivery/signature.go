@@ -0,0 +1,78 @@
+package delivery
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"hookify/internal/models"
+)
+
+// Headers defined by the Standard Webhooks spec (https://www.standardwebhooks.com).
+const (
+	headerWebhookID        = "webhook-id"
+	headerWebhookTimestamp = "webhook-timestamp"
+	headerWebhookSignature = "webhook-signature"
+
+	headerLegacySecret = "X-Secret"
+
+	// headerWebhookSequence carries the event's per-webhook sequence number.
+	headerWebhookSequence = "webhook-sequence"
+
+	// secretPrefix marks a base64-encoded Standard Webhooks secret.
+	secretPrefix = "whsec_"
+)
+
+// setAuthHeaders authenticates the request according to the webhook's signature
+// scheme. Every currently valid secret contributes a signature (or, for legacy
+// webhooks, an X-Secret value) so receivers keep working during secret rotation.
+func setAuthHeaders(req *http.Request, webhook models.Webhook, eventID int64, payload string, now time.Time) {
+	secrets := webhook.ValidSecrets(now)
+
+	if webhook.SignatureScheme == models.SignatureSchemeLegacy {
+		for _, secret := range secrets {
+			if secret != "" {
+				req.Header.Add(headerLegacySecret, secret)
+			}
+		}
+		return
+	}
+
+	msgID := strconv.FormatInt(eventID, 10)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	signatures := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret != "" {
+			signatures = append(signatures, sign(secret, msgID, timestamp, payload))
+		}
+	}
+
+	req.Header.Set(headerWebhookID, msgID)
+	req.Header.Set(headerWebhookTimestamp, timestamp)
+	req.Header.Set(headerWebhookSignature, strings.Join(signatures, " "))
+}
+
+// sign returns a "v1,<base64>" HMAC-SHA256 signature over "id.timestamp.payload".
+func sign(secret, msgID, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, signingKey(secret))
+	mac.Write([]byte(msgID + "." + timestamp + "." + payload))
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signingKey derives the HMAC key the same way Standard Webhooks libraries do
+// for "whsec_"-prefixed secrets: the rest of the secret is base64-decoded.
+// Any other secret, such as the hex secrets issued before the prefix, is used
+// as raw bytes, which is what a receiver holding the secret string expects.
+func signingKey(secret string) []byte {
+	if trimmed, ok := strings.CutPrefix(secret, secretPrefix); ok {
+		if key, err := base64.StdEncoding.DecodeString(trimmed); err == nil {
+			return key
+		}
+	}
+	return []byte(secret)
+}
@@ -3,9 +3,12 @@ package kafka
 import (
 	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
+	"hash/fnv"
 	"hookify/internal/models"
 	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
@@ -15,94 +18,275 @@ type Handler interface {
 	HandleEvent(ctx context.Context, event models.RawEvent) error
 }
 
+type DeadLetterSaver interface {
+	SaveDeadLetter(ctx context.Context, deadLetter models.DeadLetter) (int64, error)
+}
+
+// messageReader is the part of *kafka.Reader the consumer relies on.
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// messageWriter is the part of *kafka.Writer used for the DLQ topic.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// workerQueueSize is how many fetched messages may wait for each worker
+// before fetching pauses.
+const workerQueueSize = 100
+
+// commitTimeout bounds an offset commit. Commits do not use the run context,
+// so messages handled while the consumer shuts down are still committed.
+const commitTimeout = 10 * time.Second
+
 type Consumer struct {
-	log     *slog.Logger
-	readers []*kafka.Reader
-	handler Handler
-	workers int
+	log         *slog.Logger
+	reader      messageReader
+	handler     Handler
+	deadLetters DeadLetterSaver
+	dlq         messageWriter
+	retryPolicy models.RetryPolicy
+	workers     int
+	commitMu    sync.Mutex
 }
 
-func NewConsumer(log *slog.Logger, brokers []string, topic, groupID string, handler Handler, workers int) *Consumer {
+// NewConsumer creates a consumer reading the topic with a single reader that
+// dispatches messages to workers workers by message key, the webhook ID, so
+// events of one webhook are handled in order while other webhooks progress
+// in parallel.
+//
+// A message whose handling fails is retried in place following retryPolicy,
+// which must bound the number of attempts, and is then dead-lettered. Dead
+// letters are kept in deadLetters and, when dlqTopic is not empty, also
+// forwarded to that topic. An offset is only committed once every message of
+// the partition up to it has been handled or dead-lettered, so neither a
+// failure nor a faster worker lets a commit skip a message.
+func NewConsumer(log *slog.Logger, brokers []string, topic, groupID, dlqTopic string, handler Handler, deadLetters DeadLetterSaver, retryPolicy models.RetryPolicy, workers int) *Consumer {
 	if workers <= 0 {
 		workers = 1
 	}
 
-	readers := make([]*kafka.Reader, 0, workers)
-	for i := 0; i < workers; i++ {
-		readers = append(readers, kafka.NewReader(kafka.ReaderConfig{
+	c := &Consumer{
+		log: log,
+		reader: kafka.NewReader(kafka.ReaderConfig{
 			Brokers:     brokers,
 			Topic:       topic,
 			GroupID:     groupID,
 			StartOffset: kafka.FirstOffset,
 			MaxBytes:    10e6,
-		}))
+		}),
+		handler:     handler,
+		deadLetters: deadLetters,
+		retryPolicy: retryPolicy,
+		workers:     workers,
 	}
-
-	return &Consumer{
-		log:     log,
-		readers: readers,
-		handler: handler,
-		workers: workers,
+	if dlqTopic != "" {
+		c.dlq = &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  dlqTopic,
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+			WriteTimeout:           10 * time.Second,
+			ReadTimeout:            10 * time.Second,
+		}
 	}
+	return c
 }
 
 func (c *Consumer) Close() error {
-	var firstErr error
-	for _, r := range c.readers {
-		if err := r.Close(); err != nil && firstErr == nil {
-			firstErr = err
+	err := c.reader.Close()
+	if c.dlq != nil {
+		if dlqErr := c.dlq.Close(); dlqErr != nil && err == nil {
+			err = dlqErr
 		}
 	}
-	return firstErr
+	return err
 }
 
+// Run fetches and dispatches messages until ctx is done. Messages still
+// queued or being retried at that point are left uncommitted and fetched
+// again after a restart; the offsets of messages handled by then are
+// committed before Run returns.
 func (c *Consumer) Run(ctx context.Context) error {
-	errCh := make(chan error, len(c.readers))
+	offsets := newOffsetTracker()
 
-	for _, r := range c.readers {
-		reader := r
+	var wg sync.WaitGroup
+	queues := make([]chan kafka.Message, c.workers)
+	for i := range queues {
+		queues[i] = make(chan kafka.Message, workerQueueSize)
+		wg.Add(1)
 		go func() {
-			errCh <- c.runReader(ctx, reader)
+			defer wg.Done()
+			c.runWorker(ctx, queues[i], offsets)
 		}()
 	}
-
-	var firstErr error
-	for range c.readers {
-		if err := <-errCh; err != nil && firstErr == nil {
-			firstErr = err
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
 		}
-	}
-	return firstErr
-}
+		wg.Wait()
+	}()
 
-func (c *Consumer) runReader(ctx context.Context, reader *kafka.Reader) error {
 	for {
-		m, err := reader.FetchMessage(ctx)
+		m, err := c.reader.FetchMessage(ctx)
 		if err != nil {
-			if errors.Is(err, context.Canceled) {
+			if ctx.Err() != nil {
 				return nil
 			}
 			c.log.Error("failed to fetch message", "error", err)
-			time.Sleep(time.Second)
+			if sleep(ctx, time.Second) != nil {
+				return nil
+			}
 			continue
 		}
 
-		var event models.RawEvent
-		if err := json.Unmarshal(m.Value, &event); err != nil {
-			c.log.Error("failed to unmarshal message", "error", err)
-			if err := reader.CommitMessages(ctx, m); err != nil {
-				c.log.Error("failed to commit poison message", "error", err)
-			}
-			continue
+		offsets.start(m)
+		select {
+		case queues[c.workerFor(m)] <- m:
+		case <-ctx.Done():
+			return nil
 		}
+	}
+}
 
-		if err := c.handler.HandleEvent(ctx, event); err != nil {
-			c.log.Error("failed to handle event", "error", err)
+// workerFor picks the worker for a message by its key, so messages with the
+// same key are always handled by the same worker, in order.
+func (c *Consumer) workerFor(m kafka.Message) int {
+	h := fnv.New32a()
+	_, _ = h.Write(m.Key)
+	return int(h.Sum32() % uint32(c.workers))
+}
+
+func (c *Consumer) runWorker(ctx context.Context, queue <-chan kafka.Message, offsets *offsetTracker) {
+	for m := range queue {
+		if ctx.Err() != nil {
+			continue
+		}
+		if err := c.process(ctx, m); err != nil {
 			continue
 		}
+		c.complete(ctx, offsets, m)
+	}
+}
+
+// complete marks a message as handled and commits what that made contiguous.
+// Completing and committing happen under one lock so commits of a partition
+// never go backwards.
+func (c *Consumer) complete(ctx context.Context, offsets *offsetTracker, m kafka.Message) {
+	c.commitMu.Lock()
+	defer c.commitMu.Unlock()
 
-		if err := reader.CommitMessages(ctx, m); err != nil {
-			c.log.Error("failed to commit message", "error", err)
+	commit, ok := offsets.done(m)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), commitTimeout)
+	defer cancel()
+	if err := c.reader.CommitMessages(ctx, commit); err != nil {
+		c.log.Error("failed to commit message", "partition", commit.Partition, "offset", commit.Offset, "error", err)
+	}
+}
+
+// process handles a message until it succeeds or has been dead-lettered. It
+// only returns an error when ctx is done before either happened.
+func (c *Consumer) process(ctx context.Context, m kafka.Message) error {
+	var event models.RawEvent
+	if err := json.Unmarshal(m.Value, &event); err != nil {
+		c.log.Error("failed to unmarshal message", "partition", m.Partition, "offset", m.Offset, "error", err)
+		deadLetter := models.DeadLetter{
+			Source: models.DeadLetterSourceConsumer,
+			// Postgres text cannot hold invalid UTF-8 or NUL bytes.
+			Payload:   strings.ReplaceAll(strings.ToValidUTF8(string(m.Value), "\uFFFD"), "\x00", ""),
+			Reason:    models.DeadLetterReasonMalformedMessage,
+			LastError: err.Error(),
+		}
+		return c.deadLetter(ctx, m, deadLetter)
+	}
+
+	for attempts := 1; ; attempts++ {
+		err := c.handler.HandleEvent(ctx, event)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if c.retryPolicy.Exhausted(attempts, 0) {
+			c.log.Error("failed to handle event, giving up", "event_id", event.ID, "attempts", attempts, "error", err)
+			deadLetter := models.DeadLetter{
+				Source:    models.DeadLetterSourceConsumer,
+				EventID:   event.ID,
+				WebhookID: event.WebhookID,
+				Type:      models.OutboxTypeDelivery,
+				Payload:   event.Payload,
+				Reason:    models.DeadLetterReasonRetriesExhausted,
+				LastError: err.Error(),
+				Attempts:  attempts,
+			}
+			return c.deadLetter(ctx, m, deadLetter)
+		}
+
+		c.log.Warn("failed to handle event, retrying", "event_id", event.ID, "attempts", attempts, "error", err)
+		if err := sleep(ctx, c.retryPolicy.Backoff(attempts)); err != nil {
+			return err
+		}
+	}
+}
+
+// deadLetter keeps a message the consumer gave up on, forwarding the original
+// message to the DLQ topic first when one is configured. As the message must
+// not be committed before it is kept, this retries until it succeeds or ctx is
+// done; a retry may forward the message to the DLQ topic more than once.
+func (c *Consumer) deadLetter(ctx context.Context, m kafka.Message, deadLetter models.DeadLetter) error {
+	for attempts := 1; ; attempts++ {
+		err := c.saveDeadLetter(ctx, m, deadLetter)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		c.log.Error("failed to dead-letter message, retrying", "partition", m.Partition, "offset", m.Offset, "attempts", attempts, "error", err)
+		if err := sleep(ctx, c.retryPolicy.Backoff(attempts)); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Consumer) saveDeadLetter(ctx context.Context, m kafka.Message, deadLetter models.DeadLetter) error {
+	if c.dlq != nil {
+		headers := append(append([]kafka.Header(nil), m.Headers...),
+			kafka.Header{Key: "hookify-dlq-reason", Value: []byte(deadLetter.Reason)},
+			kafka.Header{Key: "hookify-dlq-error", Value: []byte(deadLetter.LastError)},
+			kafka.Header{Key: "hookify-dlq-source", Value: []byte(fmt.Sprintf("%s/%d/%d", m.Topic, m.Partition, m.Offset))},
+		)
+		if err := c.dlq.WriteMessages(ctx, kafka.Message{Key: m.Key, Value: m.Value, Headers: headers}); err != nil {
+			return fmt.Errorf("failed to write to dlq topic: %w", err)
 		}
 	}
+
+	if _, err := c.deadLetters.SaveDeadLetter(ctx, deadLetter); err != nil {
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
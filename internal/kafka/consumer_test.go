@@ -0,0 +1,387 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hookify/internal/models"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// fakeReader serves queued messages and then blocks until the run is cancelled.
+type fakeReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	committed []kafka.Message
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 {
+		m := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return m, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+// committedOffset returns the next offset the group resumes from on partition.
+func (r *fakeReader) committedOffset(partition int) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	next := int64(-1)
+	for _, m := range r.committed {
+		if m.Partition == partition && m.Offset+1 > next {
+			next = m.Offset + 1
+		}
+	}
+	return next
+}
+
+// handlerMock fails an event's first failures[id] attempts.
+type handlerMock struct {
+	mu       sync.Mutex
+	failures map[int64]int
+	attempts map[int64]int
+	handled  []int64
+}
+
+func (h *handlerMock) HandleEvent(ctx context.Context, event models.RawEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.attempts == nil {
+		h.attempts = make(map[int64]int)
+	}
+	h.attempts[event.ID]++
+	if h.attempts[event.ID] <= h.failures[event.ID] {
+		return errors.New("database unavailable")
+	}
+	h.handled = append(h.handled, event.ID)
+	return nil
+}
+
+type deadLetterSaverMock struct {
+	mu       sync.Mutex
+	failures int
+	saved    []models.DeadLetter
+}
+
+func (m *deadLetterSaverMock) SaveDeadLetter(ctx context.Context, deadLetter models.DeadLetter) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failures > 0 {
+		m.failures--
+		return 0, errors.New("database unavailable")
+	}
+	m.saved = append(m.saved, deadLetter)
+	return int64(len(m.saved)), nil
+}
+
+func eventMessage(t *testing.T, partition int, offset int64, eventID int64) kafka.Message {
+	t.Helper()
+	return webhookEventMessage(t, partition, offset, eventID, 2)
+}
+
+func webhookEventMessage(t *testing.T, partition int, offset int64, eventID int64, webhookID int64) kafka.Message {
+	t.Helper()
+	value, err := json.Marshal(models.RawEvent{ID: eventID, WebhookID: webhookID, Payload: `{}`})
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	key := []byte(strconv.FormatInt(webhookID, 10))
+	return kafka.Message{Topic: "events", Partition: partition, Offset: offset, Key: key, Value: value}
+}
+
+func newTestConsumer(handler Handler, deadLetters DeadLetterSaver, workers int) *Consumer {
+	return &Consumer{
+		log:         slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		handler:     handler,
+		deadLetters: deadLetters,
+		retryPolicy: models.RetryPolicy{InitialInterval: time.Millisecond, Multiplier: 1, MaxInterval: time.Millisecond, MaxAttempts: 3},
+		workers:     workers,
+	}
+}
+
+// runFake runs the consumer over messages until the partition 0 commit reaches
+// wantOffset, then stops it.
+func runFake(t *testing.T, c *Consumer, wantOffset int64, messages ...kafka.Message) *fakeReader {
+	t.Helper()
+	reader := &fakeReader{messages: messages}
+	c.reader = reader
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	waitFor(t, func() bool { return reader.committedOffset(0) >= wantOffset })
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	return reader
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRun_FailedMessageIsRetriedBeforeLaterCommits(t *testing.T) {
+	handler := &handlerMock{failures: map[int64]int{1: 2}}
+	c := newTestConsumer(handler, &deadLetterSaverMock{}, 1)
+
+	reader := runFake(t, c, 2, eventMessage(t, 0, 0, 1), eventMessage(t, 0, 1, 2))
+
+	if len(handler.handled) != 2 || handler.handled[0] != 1 || handler.handled[1] != 2 {
+		t.Fatalf("expected both events handled in order, got %v", handler.handled)
+	}
+	if len(reader.committed) != 2 || reader.committed[0].Offset != 0 || reader.committed[1].Offset != 1 {
+		t.Fatalf("expected offsets committed in order, got %#v", reader.committed)
+	}
+}
+
+func TestRun_ExhaustedMessageIsDeadLettered(t *testing.T) {
+	handler := &handlerMock{failures: map[int64]int{1: 10}}
+	deadLetters := &deadLetterSaverMock{}
+	c := newTestConsumer(handler, deadLetters, 1)
+
+	reader := runFake(t, c, 2, eventMessage(t, 0, 0, 1), eventMessage(t, 0, 1, 2))
+
+	if handler.attempts[1] != 3 {
+		t.Fatalf("expected 3 attempts, got %d", handler.attempts[1])
+	}
+	if len(deadLetters.saved) != 1 {
+		t.Fatalf("expected one dead letter, got %#v", deadLetters.saved)
+	}
+	dl := deadLetters.saved[0]
+	if dl.Source != models.DeadLetterSourceConsumer || dl.Reason != models.DeadLetterReasonRetriesExhausted || dl.EventID != 1 || !dl.Requeueable() {
+		t.Fatalf("unexpected dead letter %#v", dl)
+	}
+	if got := reader.committedOffset(0); got != 2 {
+		t.Fatalf("expected partition committed up to offset 2, got %d", got)
+	}
+}
+
+func TestRun_MalformedMessageIsDeadLettered(t *testing.T) {
+	deadLetters := &deadLetterSaverMock{}
+	c := newTestConsumer(&handlerMock{}, deadLetters, 1)
+
+	reader := runFake(t, c, 1, kafka.Message{Topic: "events", Offset: 0, Value: []byte("{\x00\xff")})
+
+	if len(deadLetters.saved) != 1 || deadLetters.saved[0].Reason != models.DeadLetterReasonMalformedMessage {
+		t.Fatalf("expected a malformed message dead letter, got %#v", deadLetters.saved)
+	}
+	if deadLetters.saved[0].Payload != "{�" {
+		t.Fatalf("expected payload to be storable text, got %q", deadLetters.saved[0].Payload)
+	}
+	if got := reader.committedOffset(0); got != 1 {
+		t.Fatalf("expected malformed message to be committed, got %d", got)
+	}
+}
+
+func TestRun_NotCommittedUntilDeadLettered(t *testing.T) {
+	deadLetters := &deadLetterSaverMock{failures: 2}
+	c := newTestConsumer(&handlerMock{}, deadLetters, 1)
+
+	reader := runFake(t, c, 2, kafka.Message{Topic: "events", Offset: 0, Value: []byte("not json")}, eventMessage(t, 0, 1, 2))
+
+	if len(deadLetters.saved) != 1 {
+		t.Fatalf("expected dead letter to be saved after retries, got %#v", deadLetters.saved)
+	}
+	if len(reader.committed) != 2 || reader.committed[0].Offset != 0 {
+		t.Fatalf("expected malformed message committed before the next one, got %#v", reader.committed)
+	}
+}
+
+func TestRun_CancelledMessageIsNotCommitted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler := &cancellingHandler{cancel: cancel}
+	c := newTestConsumer(handler, &deadLetterSaverMock{}, 1)
+	reader := &fakeReader{messages: []kafka.Message{eventMessage(t, 0, 0, 1)}}
+	c.reader = reader
+
+	if err := c.Run(ctx); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(reader.committed) != 0 {
+		t.Fatalf("expected nothing committed, got %#v", reader.committed)
+	}
+}
+
+// cancellingHandler fails and shuts the consumer down meanwhile.
+type cancellingHandler struct {
+	cancel context.CancelFunc
+}
+
+func (h *cancellingHandler) HandleEvent(ctx context.Context, event models.RawEvent) error {
+	h.cancel()
+	return errors.New("database unavailable")
+}
+
+func TestRun_MessageHandledDuringShutdownIsCommitted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler := &shutdownHandler{cancel: cancel}
+	c := newTestConsumer(handler, &deadLetterSaverMock{}, 1)
+	reader := &fakeReader{messages: []kafka.Message{eventMessage(t, 0, 0, 1)}}
+	c.reader = reader
+
+	if err := c.Run(ctx); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if got := reader.committedOffset(0); got != 1 {
+		t.Fatalf("expected offset 0 committed, got next offset %d", got)
+	}
+}
+
+// shutdownHandler handles an event while shutting the consumer down.
+type shutdownHandler struct {
+	cancel context.CancelFunc
+}
+
+func (h *shutdownHandler) HandleEvent(ctx context.Context, event models.RawEvent) error {
+	h.cancel()
+	return nil
+}
+
+// blockingHandler holds events of webhook 1 until release is closed.
+type blockingHandler struct {
+	handlerMock
+	release chan struct{}
+}
+
+func (h *blockingHandler) HandleEvent(ctx context.Context, event models.RawEvent) error {
+	if event.WebhookID == 1 {
+		<-h.release
+	}
+	return h.handlerMock.HandleEvent(ctx, event)
+}
+
+func (h *blockingHandler) handledEvents() []int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]int64(nil), h.handled...)
+}
+
+func TestRun_SlowWebhookDoesNotBlockOthersOrCommits(t *testing.T) {
+	handler := &blockingHandler{release: make(chan struct{})}
+	c := newTestConsumer(handler, &deadLetterSaverMock{}, 2)
+
+	slow := webhookEventMessage(t, 0, 0, 1, 1)
+	fast := webhookEventMessage(t, 0, 1, 2, 2)
+	for c.workerFor(fast) == c.workerFor(slow) {
+		var event models.RawEvent
+		_ = json.Unmarshal(fast.Value, &event)
+		fast = webhookEventMessage(t, 0, 1, 2, event.WebhookID+1)
+	}
+	reader := &fakeReader{messages: []kafka.Message{slow, fast, webhookEventMessage(t, 0, 2, 3, 1)}}
+	c.reader = reader
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	waitFor(t, func() bool { return len(handler.handledEvents()) == 1 })
+	if got := reader.committedOffset(0); got != -1 {
+		t.Fatalf("expected nothing committed while offset 0 is in flight, got %d", got)
+	}
+
+	close(handler.release)
+	waitFor(t, func() bool { return reader.committedOffset(0) == 3 })
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	handled := handler.handledEvents()
+	if len(handled) != 3 || handled[0] != 2 || handled[1] != 1 || handled[2] != 3 {
+		t.Fatalf("expected fast webhook first and slow webhook in order, got %v", handled)
+	}
+}
+
+func TestOffsetTracker_CommitsOnlyContiguousOffsets(t *testing.T) {
+	tracker := newOffsetTracker()
+	msgs := []kafka.Message{
+		{Topic: "events", Partition: 0, Offset: 5},
+		{Topic: "events", Partition: 0, Offset: 6},
+		{Topic: "events", Partition: 0, Offset: 7},
+		{Topic: "events", Partition: 1, Offset: 3},
+	}
+	for _, m := range msgs {
+		tracker.start(m)
+	}
+
+	if _, ok := tracker.done(msgs[2]); ok {
+		t.Fatalf("expected no commit while earlier offsets are in flight")
+	}
+	if _, ok := tracker.done(msgs[1]); ok {
+		t.Fatalf("expected no commit while offset 5 is in flight")
+	}
+	if commit, ok := tracker.done(msgs[3]); !ok || commit.Partition != 1 || commit.Offset != 3 {
+		t.Fatalf("expected partition 1 to commit independently, got %#v %v", commit, ok)
+	}
+	if commit, ok := tracker.done(msgs[0]); !ok || commit.Offset != 7 {
+		t.Fatalf("expected commit up to offset 7, got %#v %v", commit, ok)
+	}
+}
+
+func TestOffsetTracker_RereadPartitionStartsOver(t *testing.T) {
+	tracker := newOffsetTracker()
+	first := []kafka.Message{
+		{Topic: "events", Partition: 0, Offset: 5},
+		{Topic: "events", Partition: 0, Offset: 6},
+	}
+	for _, m := range first {
+		tracker.start(m)
+	}
+
+	// After a rebalance the partition is read again from offset 3.
+	reread := []kafka.Message{
+		{Topic: "events", Partition: 0, Offset: 3},
+		{Topic: "events", Partition: 0, Offset: 4},
+	}
+	for _, m := range reread {
+		tracker.start(m)
+	}
+
+	if _, ok := tracker.done(first[0]); ok {
+		t.Fatalf("expected no commit for a message read before the partition was read again")
+	}
+	if _, ok := tracker.done(reread[1]); ok {
+		t.Fatalf("expected no commit while offset 3 is in flight")
+	}
+	if commit, ok := tracker.done(reread[0]); !ok || commit.Offset != 4 {
+		t.Fatalf("expected commit up to offset 4, got %#v %v", commit, ok)
+	}
+}
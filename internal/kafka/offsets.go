@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// offsetTracker keeps the fetched but not yet completed messages of every
+// partition, so a commit never covers a message that is still being handled.
+// Messages of a partition may complete in any order.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[partitionKey]*partitionOffsets
+}
+
+type partitionKey struct {
+	topic     string
+	partition int
+}
+
+type partitionOffsets struct {
+	inFlight []kafka.Message
+	done     map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[partitionKey]*partitionOffsets)}
+}
+
+// start registers a fetched message as in flight. A message at or below an
+// offset already in flight means the partition is being read again, after a
+// rebalance or a redelivery, so its earlier state is dropped: completions of
+// the messages read before are then ignored, and the new messages are
+// handled again.
+func (t *offsetTracker) start(m kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := partitionKey{topic: m.Topic, partition: m.Partition}
+	p, ok := t.partitions[key]
+	if !ok {
+		p = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[key] = p
+	}
+	if n := len(p.inFlight); n > 0 && m.Offset <= p.inFlight[n-1].Offset {
+		p.inFlight = nil
+		clear(p.done)
+	}
+	p.inFlight = append(p.inFlight, m)
+}
+
+// done marks a message completed. When this completes a run of messages at the
+// head of its partition, it returns the last message of that run, which is the
+// one to commit.
+func (t *offsetTracker) done(m kafka.Message) (kafka.Message, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[partitionKey{topic: m.Topic, partition: m.Partition}]
+	if !ok || !slices.ContainsFunc(p.inFlight, func(f kafka.Message) bool { return f.Offset == m.Offset }) {
+		return kafka.Message{}, false
+	}
+	p.done[m.Offset] = true
+
+	var (
+		commit kafka.Message
+		found  bool
+	)
+	for len(p.inFlight) > 0 && p.done[p.inFlight[0].Offset] {
+		commit, found = p.inFlight[0], true
+		delete(p.done, commit.Offset)
+		p.inFlight = p.inFlight[1:]
+	}
+	return commit, found
+}
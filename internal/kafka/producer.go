@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"hookify/internal/models"
 	"log/slog"
 	"strconv"
@@ -12,13 +13,15 @@ import (
 )
 
 type Producer struct {
-	log    *slog.Logger
-	writer *kafka.Writer
+	log     *slog.Logger
+	brokers []string
+	writer  *kafka.Writer
 }
 
 func NewProducer(log *slog.Logger, brokers []string, topic string) *Producer {
 	return &Producer{
-		log: log,
+		log:     log,
+		brokers: brokers,
 		writer: &kafka.Writer{
 			Addr:                   kafka.TCP(brokers...),
 			Topic:                  topic,
@@ -35,6 +38,19 @@ func (p *Producer) Close() error {
 	return p.writer.Close()
 }
 
+// Ping reports whether any of the brokers accepts connections.
+func (p *Producer) Ping(ctx context.Context) error {
+	var err error
+	for _, broker := range p.brokers {
+		var conn *kafka.Conn
+		conn, err = kafka.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn.Close()
+		}
+	}
+	return fmt.Errorf("failed to reach kafka brokers: %w", err)
+}
+
 func (p *Producer) PublishEvent(ctx context.Context, event models.RawEvent) error {
 	value, err := json.Marshal(event)
 	if err != nil {
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Application owns a group of webhooks that receive the same messages.
+// Publishers authenticate with the application's API key, of which only the
+// SHA-256 hash is stored, and never need the keys of individual webhooks.
+type Application struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	APIKeyHash string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Message is an event published once to an application. Every webhook of the
+// application subscribed to its type gets its own RawEvent referencing the
+// message, which carries that endpoint's status, sequence and retries.
+type Message struct {
+	ID            int64     `json:"id"`
+	ApplicationID int64     `json:"application_id"`
+	EventType     string    `json:"event_type,omitempty"`
+	Payload       string    `json:"payload"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// CircuitState is the state of a webhook's circuit breaker.
+type CircuitState string
+
+const (
+	// CircuitStateClosed lets deliveries through.
+	CircuitStateClosed CircuitState = "closed"
+	// CircuitStateOpen holds deliveries until the cooldown has elapsed.
+	CircuitStateOpen CircuitState = "open"
+	// CircuitStateHalfOpen lets a single probe delivery through after the cooldown.
+	CircuitStateHalfOpen CircuitState = "half_open"
+)
+
+// Circuit is the persisted circuit breaker of a webhook. A webhook without a
+// stored circuit is treated as closed. OpenUntil is when an open circuit may
+// be probed, or when a half-open probe is considered lost.
+type Circuit struct {
+	WebhookID           int64        `json:"webhook_id"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenUntil           *time.Time   `json:"open_until,omitempty"`
+	UpdatedAt           time.Time    `json:"updated_at"`
+}
+
+// CircuitPolicy controls the per-webhook circuit breaker.
+//
+// The circuit opens after FailureThreshold consecutive retriable failures and
+// lets a probe through once Cooldown has elapsed. The webhook is disabled once
+// the streak reaches DisableThreshold; zero never disables it.
+type CircuitPolicy struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+	DisableThreshold int
+}
+
+func (p CircuitPolicy) Validate() error {
+	switch {
+	case p.FailureThreshold < 1:
+		return errors.New("failure threshold must be >= 1")
+	case p.Cooldown <= 0:
+		return errors.New("cooldown must be > 0")
+	case p.DisableThreshold < 0:
+		return errors.New("disable threshold must be >= 0")
+	case p.DisableThreshold > 0 && p.DisableThreshold < p.FailureThreshold:
+		return errors.New("disable threshold must not be below the failure threshold")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// DeadLetterSource tells which stage gave up on a dead letter.
+type DeadLetterSource string
+
+const (
+	// DeadLetterSourceOutbox entries were dropped by the outbox worker or by a
+	// delivery that failed permanently.
+	DeadLetterSourceOutbox DeadLetterSource = "outbox"
+	// DeadLetterSourceConsumer entries are Kafka messages the consumer could not process.
+	DeadLetterSourceConsumer DeadLetterSource = "consumer"
+)
+
+const (
+	DeadLetterReasonRetriesExhausted = "retries_exhausted"
+	DeadLetterReasonPermanentFailure = "permanent_failure"
+	DeadLetterReasonMalformedMessage = "malformed_message"
+)
+
+// DeadLetter keeps work that hookify gave up on, with the original payload so
+// it can be inspected and requeued. EventID and WebhookID are zero when the
+// payload could not be attributed, in which case it cannot be requeued.
+type DeadLetter struct {
+	ID        int64            `json:"id"`
+	Source    DeadLetterSource `json:"source"`
+	EventID   int64            `json:"event_id,omitempty"`
+	WebhookID int64            `json:"webhook_id,omitempty"`
+	Type      OutboxType       `json:"type,omitempty"`
+	Payload   string           `json:"payload"`
+	Reason    string           `json:"reason"`
+	LastError string           `json:"last_error"`
+	Attempts  int              `json:"attempts"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// Requeueable reports whether the dead letter carries enough to be queued again.
+func (d DeadLetter) Requeueable() bool {
+	return d.EventID > 0 && d.WebhookID > 0 && d.Type != ""
+}
+
+// DeadLetterFilter narrows a dead letter listing or purge. Zero-valued fields are not applied.
+type DeadLetterFilter struct {
+	WebhookID     int64
+	Source        DeadLetterSource
+	CreatedBefore time.Time
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrEgressDenied = errors.New("egress denied")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which
+// netip.Addr.IsPrivate does not cover.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// EgressPolicy restricts where webhook deliveries may be sent.
+//
+// Empty AllowedSchemes or AllowedPorts do not restrict schemes or ports.
+// Unless AllowPrivateNetworks is set, loopback, private, link-local and other
+// non-public addresses are refused; it is meant for development only.
+type EgressPolicy struct {
+	AllowedSchemes       []string
+	AllowedPorts         []int
+	AllowPrivateNetworks bool
+}
+
+// CheckURL validates the scheme and port of a target URL and refuses hosts
+// that are known to be internal without resolving them. The dialed address
+// still has to pass CheckIP, as a public name may resolve to an internal one.
+func (p EgressPolicy) CheckURL(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if len(p.AllowedSchemes) > 0 && !slices.Contains(p.AllowedSchemes, scheme) {
+		return fmt.Errorf("%w: scheme %q is not allowed", ErrEgressDenied, u.Scheme)
+	}
+
+	port, err := urlPort(u)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEgressDenied, err)
+	}
+	if err := p.CheckPort(port); err != nil {
+		return err
+	}
+
+	if p.AllowPrivateNetworks {
+		return nil
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: host %q is not allowed", ErrEgressDenied, u.Hostname())
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return p.CheckIP(addr)
+	}
+
+	return nil
+}
+
+func (p EgressPolicy) CheckPort(port int) error {
+	if len(p.AllowedPorts) > 0 && !slices.Contains(p.AllowedPorts, port) {
+		return fmt.Errorf("%w: port %d is not allowed", ErrEgressDenied, port)
+	}
+	return nil
+}
+
+// CheckIP refuses addresses outside the public unicast space unless private
+// networks are allowed.
+func (p EgressPolicy) CheckIP(addr netip.Addr) error {
+	if p.AllowPrivateNetworks {
+		return nil
+	}
+
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: address %s is not public", ErrEgressDenied, addr)
+	}
+	return nil
+}
+
+func urlPort(u *url.URL) (int, error) {
+	if p := u.Port(); p != "" {
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, fmt.Errorf("invalid port %q", p)
+		}
+		return port, nil
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return 80, nil
+	case "https":
+		return 443, nil
+	default:
+		return 0, fmt.Errorf("no default port for scheme %q", u.Scheme)
+	}
+}
@@ -3,5 +3,9 @@ package models
 import "errors"
 
 var (
-	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrEventNotFound       = errors.New("event not found")
+	ErrDeadLetterNotFound  = errors.New("dead letter not found")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrMessageNotFound     = errors.New("message not found")
 )
@@ -11,19 +11,150 @@ const (
 	EventStatusPending   EventStatus = "pending"
 	EventStatusDelivered EventStatus = "delivered"
 	EventStatusFailed    EventStatus = "failed"
+	// EventStatusFiltered marks an event its webhook is not subscribed to.
+	EventStatusFiltered EventStatus = "filtered"
 )
 
+// SignatureScheme selects how outbound deliveries are authenticated.
+type SignatureScheme string
+
+const (
+	// SignatureSchemeStandard signs deliveries with HMAC-SHA256 per the Standard Webhooks spec.
+	SignatureSchemeStandard SignatureScheme = "standard"
+	// SignatureSchemeLegacy sends the raw secret in the X-Secret header.
+	SignatureSchemeLegacy SignatureScheme = "legacy"
+)
+
+// WebhookStatus tells whether deliveries to a webhook are attempted.
+type WebhookStatus string
+
+const (
+	WebhookStatusActive WebhookStatus = "active"
+	// WebhookStatusPaused holds deliveries in the outbox until the webhook is resumed.
+	WebhookStatusPaused WebhookStatus = "paused"
+	// WebhookStatusDisabled is set automatically when an endpoint reports it no longer exists.
+	WebhookStatusDisabled WebhookStatus = "disabled"
+)
+
+// Webhook is a receiving endpoint. Secret signs outbound deliveries and is never
+// accepted from publishers; publishers authenticate with the API key, of which
+// only the SHA-256 hash is stored. An Ordered webhook receives its events
+// strictly in sequence: an event is only delivered once every earlier event
+// has been delivered or given up on. EventTypes lists the event type patterns
+// the webhook is subscribed to; when empty it receives every event. A webhook
+// with an ApplicationID also receives the messages published to that
+// application.
 type Webhook struct {
-	ID     int64  `json:"id"`
-	URL    string `json:"url"`
-	Secret string `json:"secret"`
+	ID                      int64           `json:"id"`
+	ApplicationID           int64           `json:"application_id,omitempty"`
+	URL                     string          `json:"url"`
+	Secret                  string          `json:"secret"`
+	PreviousSecret          string          `json:"previous_secret,omitempty"`
+	PreviousSecretExpiresAt *time.Time      `json:"previous_secret_expires_at,omitempty"`
+	APIKeyHash              string          `json:"-"`
+	PreviousAPIKeyHash      string          `json:"-"`
+	PreviousAPIKeyExpiresAt *time.Time      `json:"previous_api_key_expires_at,omitempty"`
+	SignatureScheme         SignatureScheme `json:"signature_scheme"`
+	RetryPolicy             *RetryPolicy    `json:"retry_policy,omitempty"`
+	Status                  WebhookStatus   `json:"status"`
+	DisabledReason          string          `json:"disabled_reason,omitempty"`
+	Ordered                 bool            `json:"ordered"`
+	EventTypes              []string        `json:"event_types,omitempty"`
+	CreatedAt               time.Time       `json:"created_at"`
+}
+
+// ValidSecrets returns the current signing secret followed by the previous one
+// while its rotation grace period has not yet expired.
+func (w Webhook) ValidSecrets(now time.Time) []string {
+	secrets := []string{w.Secret}
+	if w.PreviousSecret != "" && w.PreviousSecretExpiresAt != nil && now.Before(*w.PreviousSecretExpiresAt) {
+		secrets = append(secrets, w.PreviousSecret)
+	}
+	return secrets
+}
+
+// ValidAPIKeyHashes returns the current API key hash followed by the previous
+// one while its rotation grace period has not yet expired.
+func (w Webhook) ValidAPIKeyHashes(now time.Time) []string {
+	hashes := []string{w.APIKeyHash}
+	if w.PreviousAPIKeyHash != "" && w.PreviousAPIKeyExpiresAt != nil && now.Before(*w.PreviousAPIKeyExpiresAt) {
+		hashes = append(hashes, w.PreviousAPIKeyHash)
+	}
+	return hashes
 }
 
+// WebhookUpdate describes a partial update of a webhook. Nil fields are left unchanged.
+// ClearRetryPolicy removes the webhook's retry policy override and takes precedence over RetryPolicy.
+type WebhookUpdate struct {
+	URL              *string
+	SignatureScheme  *SignatureScheme
+	RetryPolicy      *RetryPolicy
+	ClearRetryPolicy bool
+	Ordered          *bool
+	EventTypes       *[]string
+}
+
+// RawEvent is an event submitted for a webhook, or the delivery of a message
+// to one of its application's webhooks, in which case MessageID is set.
+// Sequence numbers the webhook's events in submission order, starting at 1.
 type RawEvent struct {
 	ID        int64       `json:"id"`
 	WebhookID int64       `json:"webhook_id"`
+	MessageID int64       `json:"message_id,omitempty"`
+	EventType string      `json:"event_type,omitempty"`
+	Sequence  int64       `json:"sequence,omitempty"`
 	Payload   string      `json:"payload"`
 	Status    EventStatus `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// EventSubmission is one event of a batch submitted by a publisher.
+type EventSubmission struct {
+	WebhookID      int64
+	EventType      string
+	Payload        string
+	APIKey         string
+	IdempotencyKey string
+}
+
+// SubmissionResult is the outcome of one EventSubmission. Err is set when the
+// submission was rejected; Created is false when an idempotency key resolved
+// it to an event stored earlier.
+type SubmissionResult struct {
+	EventID int64
+	Created bool
+	Err     error
+}
+
+// MinReplayRate and MaxReplayRate bound the rate, in events per second, at
+// which replayed events are delivered. Slower rates would space deliveries
+// beyond what a time.Duration can hold.
+const (
+	MinReplayRate = 0.001
+	MaxReplayRate = 10000.0
+)
+
+// EventFilter narrows an event listing. Zero-valued fields are not applied.
+type EventFilter struct {
+	WebhookID     int64
+	MessageID     int64
+	Status        EventStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// DeliveryAttempt records a single HTTP request made to a webhook endpoint.
+// StatusCode is zero when no response was received.
+type DeliveryAttempt struct {
+	ID           int64         `json:"id"`
+	EventID      int64         `json:"event_id"`
+	WebhookID    int64         `json:"webhook_id"`
+	Attempt      int           `json:"attempt"`
+	RequestedAt  time.Time     `json:"requested_at"`
+	StatusCode   int           `json:"status_code"`
+	ResponseBody string        `json:"response_body"`
+	Latency      time.Duration `json:"latency"`
+	Error        string        `json:"error"`
 }
 
 type OutboxType string
@@ -38,6 +169,8 @@ type OutboxEntry struct {
 	Type          OutboxType `json:"type"`
 	EventID       int64      `json:"event_id"`
 	WebhookID     int64      `json:"webhook_id"`
+	EventType     string     `json:"event_type"`
+	Sequence      int64      `json:"sequence"`
 	Payload       string     `json:"payload"`
 	Attempts      int        `json:"attempts"`
 	NextAttemptAt time.Time  `json:"next_attempt_at"`
@@ -2,7 +2,11 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
+	"net/netip"
+	"net/url"
 	"testing"
+	"time"
 )
 
 func TestRawEvent_UnmarshalJSON_HookIDAlias(t *testing.T) {
@@ -19,3 +23,162 @@ func TestRawEvent_UnmarshalJSON_HookIDAlias(t *testing.T) {
 		t.Fatalf("expected ID=1, got %d", e.ID)
 	}
 }
+
+func TestWebhook_ValidSecrets(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Minute)
+	w := Webhook{Secret: "new", PreviousSecret: "old", PreviousSecretExpiresAt: &expiresAt}
+
+	if got := w.ValidSecrets(now); len(got) != 2 || got[0] != "new" || got[1] != "old" {
+		t.Fatalf("expected current and previous secret, got %#v", got)
+	}
+	if got := w.ValidSecrets(expiresAt); len(got) != 1 || got[0] != "new" {
+		t.Fatalf("expected only current secret after expiry, got %#v", got)
+	}
+}
+
+func TestRetryPolicy_BackoffGrowsAndCaps(t *testing.T) {
+	p := RetryPolicy{InitialInterval: time.Second, Multiplier: 2, MaxInterval: 10 * time.Second}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := p.Backoff(i + 1); got != w {
+			t.Fatalf("attempt %d: expected %v, got %v", i+1, w, got)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffJitterBounds(t *testing.T) {
+	p := RetryPolicy{InitialInterval: 10 * time.Second, Multiplier: 1, MaxInterval: time.Minute, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		got := p.Backoff(1)
+		if got < 5*time.Second || got > 15*time.Second {
+			t.Fatalf("expected backoff within ±50%% of 10s, got %v", got)
+		}
+	}
+}
+
+func TestRetryPolicy_Exhausted(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, MaxAge: time.Hour}
+
+	if p.Exhausted(2, time.Minute) {
+		t.Fatalf("expected policy not to be exhausted")
+	}
+	if !p.Exhausted(3, time.Minute) {
+		t.Fatalf("expected policy to be exhausted by attempts")
+	}
+	if !p.Exhausted(1, time.Hour) {
+		t.Fatalf("expected policy to be exhausted by age")
+	}
+	if (RetryPolicy{}).Exhausted(1000, 1000*time.Hour) {
+		t.Fatalf("expected zero limits to be unlimited")
+	}
+}
+
+func TestEgressPolicy_CheckIP(t *testing.T) {
+	p := EgressPolicy{}
+	for _, tt := range []struct {
+		addr    string
+		allowed bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+	} {
+		err := p.CheckIP(netip.MustParseAddr(tt.addr))
+		if (err == nil) != tt.allowed {
+			t.Errorf("%s: expected allowed=%v, got %v", tt.addr, tt.allowed, err)
+		}
+		if err != nil && !errors.Is(err, ErrEgressDenied) {
+			t.Errorf("%s: expected ErrEgressDenied, got %v", tt.addr, err)
+		}
+	}
+
+	if err := (EgressPolicy{AllowPrivateNetworks: true}).CheckIP(netip.MustParseAddr("127.0.0.1")); err != nil {
+		t.Fatalf("expected private networks to be allowed, got %v", err)
+	}
+}
+
+func TestEgressPolicy_CheckURL(t *testing.T) {
+	p := EgressPolicy{AllowedSchemes: []string{"https"}, AllowedPorts: []int{443, 8443}}
+	for _, tt := range []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://example.com/hook", true},
+		{"https://example.com:8443/hook", true},
+		{"http://example.com/hook", false},
+		{"https://example.com:5432/", false},
+		{"https://localhost/", false},
+		{"https://api.localhost./", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://[::1]/", false},
+	} {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.url, err)
+		}
+		if err := p.CheckURL(u); (err == nil) != tt.allowed {
+			t.Errorf("%s: expected allowed=%v, got %v", tt.url, tt.allowed, err)
+		}
+	}
+}
+
+func TestMatchEventType(t *testing.T) {
+	cases := []struct {
+		pattern, eventType string
+		want               bool
+	}{
+		{"*", "invoice.paid", true},
+		{"*", "", true},
+		{"invoice.*", "invoice.paid", true},
+		{"invoice.*", "invoice.payment.failed", true},
+		{"invoice.*", "invoice", false},
+		{"invoice.*", "invoices.paid", false},
+		{"invoice.paid", "invoice.paid", true},
+		{"invoice.paid", "invoice.paid.late", false},
+		{"invoice.paid", "", false},
+	}
+	for _, c := range cases {
+		if got := MatchEventType(c.pattern, c.eventType); got != c.want {
+			t.Fatalf("MatchEventType(%q, %q) = %v, want %v", c.pattern, c.eventType, got, c.want)
+		}
+	}
+}
+
+func TestWebhook_SubscribesTo(t *testing.T) {
+	if !(Webhook{}).SubscribesTo("anything") {
+		t.Fatalf("expected webhook without subscriptions to receive every event")
+	}
+	w := Webhook{EventTypes: []string{"invoice.*", "customer.created"}}
+	if !w.SubscribesTo("customer.created") || !w.SubscribesTo("invoice.paid") {
+		t.Fatalf("expected subscribed types to match")
+	}
+	if w.SubscribesTo("customer.deleted") || w.SubscribesTo("") {
+		t.Fatalf("expected other types to be filtered")
+	}
+}
+
+func TestValidEventTypePattern(t *testing.T) {
+	for _, p := range []string{"*", "invoice", "invoice.paid", "invoice.*", "a.b.*"} {
+		if !ValidEventTypePattern(p) {
+			t.Fatalf("expected %q to be valid", p)
+		}
+	}
+	for _, p := range []string{"", ".*", "invoice.", "invoice..paid", "*.paid", "invoice*", "in voice", "invoice.*.paid"} {
+		if ValidEventTypePattern(p) {
+			t.Fatalf("expected %q to be invalid", p)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how failed outbox entries are rescheduled.
+//
+// The n-th retry waits InitialInterval * Multiplier^(n-1), capped at
+// MaxInterval and randomised by ±Jitter (a fraction between 0 and 1).
+// Entries are given up once MaxAttempts attempts have failed or they are
+// older than MaxAge; a zero value disables the respective limit.
+type RetryPolicy struct {
+	InitialInterval time.Duration `json:"initial_interval"`
+	Multiplier      float64       `json:"multiplier"`
+	MaxInterval     time.Duration `json:"max_interval"`
+	Jitter          float64       `json:"jitter"`
+	MaxAttempts     int           `json:"max_attempts"`
+	MaxAge          time.Duration `json:"max_age"`
+}
+
+func (p RetryPolicy) Validate() error {
+	switch {
+	case p.InitialInterval <= 0:
+		return errors.New("initial interval must be > 0")
+	case p.Multiplier < 1:
+		return errors.New("multiplier must be >= 1")
+	case p.MaxInterval < p.InitialInterval:
+		return errors.New("max interval must be >= initial interval")
+	case p.Jitter < 0 || p.Jitter > 1:
+		return errors.New("jitter must be between 0 and 1")
+	case p.MaxAttempts < 0:
+		return errors.New("max attempts must be >= 0")
+	case p.MaxAge < 0:
+		return errors.New("max age must be >= 0")
+	}
+	return nil
+}
+
+// Backoff returns the delay before the next attempt once attempts attempts have failed.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	delay := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempts-1))
+	if delay > float64(p.MaxInterval) {
+		delay = float64(p.MaxInterval)
+	}
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	if delay > float64(p.MaxInterval) {
+		delay = float64(p.MaxInterval)
+	}
+
+	return time.Duration(delay)
+}
+
+// Exhausted reports whether an entry with attempts failed attempts and the given age should be given up.
+func (p RetryPolicy) Exhausted(attempts int, age time.Duration) bool {
+	if p.MaxAttempts > 0 && attempts >= p.MaxAttempts {
+		return true
+	}
+	return p.MaxAge > 0 && age >= p.MaxAge
+}
@@ -0,0 +1,61 @@
+package models
+
+import "strings"
+
+// MaxEventTypeLength bounds event types and subscription patterns.
+const MaxEventTypeLength = 255
+
+// SubscribesTo reports whether the webhook receives events of eventType. A
+// webhook without subscriptions receives every event.
+func (w Webhook) SubscribesTo(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, pattern := range w.EventTypes {
+		if MatchEventType(pattern, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchEventType reports whether eventType matches a subscription pattern. The
+// pattern "*" matches every event, including untyped ones. A pattern ending in
+// ".*" matches every type below its prefix, so "invoice.*" matches
+// "invoice.paid" and "invoice.payment.failed" but not "invoice". Any other
+// pattern matches only the identical type.
+func MatchEventType(pattern, eventType string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(eventType, prefix) && len(eventType) > len(prefix)
+	}
+	return pattern == eventType
+}
+
+// ValidEventType reports whether t can be given to an event: dot-separated
+// non-empty segments without wildcards or whitespace.
+func ValidEventType(t string) bool {
+	if t == "" || len(t) > MaxEventTypeLength {
+		return false
+	}
+	for _, segment := range strings.Split(t, ".") {
+		if segment == "" || strings.ContainsAny(segment, "* \t\r\n") {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidEventTypePattern reports whether p is "*", an event type, or an event
+// type followed by ".*".
+func ValidEventTypePattern(p string) bool {
+	if p == "*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(p, ".*"); ok {
+		return ValidEventType(prefix)
+	}
+	return ValidEventType(p)
+}
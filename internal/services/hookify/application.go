@@ -0,0 +1,93 @@
+package hookify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hookify/internal/models"
+)
+
+// CreateApplication stores an application and returns its ID together with the
+// API key publishers use for it. Only the key's hash is stored.
+func (s *Service) CreateApplication(ctx context.Context, name string) (applicationID int64, apiKey string, err error) {
+	apiKey, err = generateSecret()
+	if err != nil {
+		s.log.Error("failed to generate api key", "error", err)
+		return 0, "", err
+	}
+
+	applicationID, err = s.applications.SaveApplication(ctx, name, hashAPIKey(apiKey))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to save application: %w", err)
+	}
+
+	return applicationID, apiKey, nil
+}
+
+func (s *Service) GetApplication(ctx context.Context, applicationID int64) (models.Application, error) {
+	app, err := s.applications.GetApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return models.Application{}, models.ErrApplicationNotFound
+		}
+		return models.Application{}, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return app, nil
+}
+
+// DeleteApplication removes the application with its webhooks, messages and
+// their events.
+func (s *Service) DeleteApplication(ctx context.Context, applicationID int64) error {
+	if err := s.applications.DeleteApplication(ctx, applicationID); err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return models.ErrApplicationNotFound
+		}
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+
+	return nil
+}
+
+// PublishMessage stores a message for the application and queues one delivery
+// per subscribed webhook, returned as the events tracking each of them. A
+// message no webhook is subscribed to is stored without deliveries.
+func (s *Service) PublishMessage(ctx context.Context, applicationID int64, eventType string, payload string, apiKey string) (models.Message, []models.RawEvent, error) {
+	app, err := s.GetApplication(ctx, applicationID)
+	if err != nil {
+		return models.Message{}, nil, err
+	}
+
+	if !apiKeyMatches([]string{app.APIKeyHash}, apiKey) {
+		return models.Message{}, nil, ErrInvalidAPIKey
+	}
+
+	if !json.Valid([]byte(payload)) {
+		return models.Message{}, nil, ErrInvalidPayload
+	}
+
+	message, events, err := s.applications.SaveMessageWithOutbox(ctx, applicationID, eventType, payload)
+	if err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return models.Message{}, nil, models.ErrApplicationNotFound
+		}
+		return models.Message{}, nil, fmt.Errorf("failed to save message: %w", err)
+	}
+
+	s.log.Info("message published", "message_id", message.ID, "application_id", applicationID, "deliveries", len(events))
+
+	return message, events, nil
+}
+
+func (s *Service) GetMessage(ctx context.Context, messageID int64) (models.Message, error) {
+	message, err := s.applications.GetMessage(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, models.ErrMessageNotFound) {
+			return models.Message{}, models.ErrMessageNotFound
+		}
+		return models.Message{}, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,70 @@
+package hookify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hookify/internal/models"
+	"time"
+)
+
+// SubmitEvents stores a batch of events in one transaction. Each webhook is
+// looked up once per batch. The returned results line up with submissions; a
+// submission that is rejected carries its error in the result and does not
+// affect the others. The error return is only set when the batch as a whole
+// could not be stored.
+func (s *Service) SubmitEvents(ctx context.Context, submissions []models.EventSubmission) ([]models.SubmissionResult, error) {
+	var (
+		results  = make([]models.SubmissionResult, len(submissions))
+		webhooks = make(map[int64]*models.Webhook)
+		accepted []models.EventSubmission
+		indexes  []int
+		now      = time.Now()
+	)
+	for i, sub := range submissions {
+		webhook, ok := webhooks[sub.WebhookID]
+		if !ok {
+			w, err := s.webhookRepo.GetWebhook(ctx, sub.WebhookID)
+			if err != nil && !errors.Is(err, models.ErrWebhookNotFound) {
+				return nil, fmt.Errorf("failed to verify webhook existence: %w", err)
+			}
+			if err == nil {
+				webhook = &w
+			}
+			webhooks[sub.WebhookID] = webhook
+		}
+
+		if webhook == nil {
+			results[i].Err = models.ErrWebhookNotFound
+			continue
+		}
+		if err := s.authorizeSubmission(*webhook, sub.APIKey, now); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if !json.Valid([]byte(sub.Payload)) {
+			results[i].Err = ErrInvalidPayload
+			continue
+		}
+
+		accepted = append(accepted, sub)
+		indexes = append(indexes, i)
+	}
+
+	if len(accepted) == 0 {
+		return results, nil
+	}
+
+	saved, err := s.eventSaver.SaveEventsWithOutbox(ctx, accepted, s.idempotencyTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save events: %w", err)
+	}
+	for n, i := range indexes {
+		results[i] = saved[n]
+	}
+
+	s.log.Info("event batch submitted", "submitted", len(submissions), "accepted", len(accepted))
+
+	return results, nil
+}
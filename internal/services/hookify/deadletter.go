@@ -0,0 +1,60 @@
+package hookify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hookify/internal/models"
+)
+
+// ListDeadLetters returns up to limit dead letters matching the filter, newest
+// first, starting below beforeID when it is positive.
+func (s *Service) ListDeadLetters(ctx context.Context, filter models.DeadLetterFilter, beforeID int64, limit int) ([]models.DeadLetter, error) {
+	deadLetters, err := s.deadLetters.ListDeadLetters(ctx, filter, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	return deadLetters, nil
+}
+
+// RequeueDeadLetter queues the dead letter's payload again with a fresh retry
+// budget and removes it from the dead letters. Messages the consumer could not
+// attribute to an event cannot be requeued.
+func (s *Service) RequeueDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error) {
+	deadLetter, err := s.deadLetters.GetDeadLetter(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrDeadLetterNotFound) {
+			return models.DeadLetter{}, models.ErrDeadLetterNotFound
+		}
+		return models.DeadLetter{}, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	if !deadLetter.Requeueable() {
+		return models.DeadLetter{}, ErrDeadLetterNotRequeueable
+	}
+
+	deadLetter, err = s.deadLetters.RequeueDeadLetter(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrDeadLetterNotFound) || errors.Is(err, models.ErrWebhookNotFound) || errors.Is(err, models.ErrEventNotFound) {
+			return models.DeadLetter{}, err
+		}
+		s.log.Error("failed to requeue dead letter", "id", id, "error", err)
+		return models.DeadLetter{}, fmt.Errorf("failed to requeue dead letter: %w", err)
+	}
+
+	s.log.Info("dead letter requeued", "id", id, "event_id", deadLetter.EventID, "webhook_id", deadLetter.WebhookID)
+
+	return deadLetter, nil
+}
+
+// PurgeDeadLetters deletes the dead letters matching the filter and returns how many were removed.
+func (s *Service) PurgeDeadLetters(ctx context.Context, filter models.DeadLetterFilter) (int64, error) {
+	purged, err := s.deadLetters.PurgeDeadLetters(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge dead letters: %w", err)
+	}
+
+	s.log.Info("dead letters purged", "count", purged, "webhook_id", filter.WebhookID, "source", filter.Source)
+
+	return purged, nil
+}
@@ -3,5 +3,8 @@ package hookify
 import "errors"
 
 var (
-	ErrInvalidWebhookSecret = errors.New("invalid webhook secret")
+	ErrInvalidAPIKey            = errors.New("invalid api key")
+	ErrWebhookDisabled          = errors.New("webhook is disabled")
+	ErrInvalidPayload           = errors.New("payload is not valid JSON")
+	ErrDeadLetterNotRequeueable = errors.New("dead letter cannot be requeued")
 )
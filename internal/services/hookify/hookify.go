@@ -3,67 +3,391 @@ package hookify
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"hookify/internal/models"
 	"log/slog"
+	"time"
 )
 
 type Service struct {
-	log         *slog.Logger
-	webhookRepo WebhookRepository
-	eventSaver  EventSaver
+	log               *slog.Logger
+	webhookRepo       WebhookRepository
+	eventSaver        EventSaver
+	eventProvider     EventProvider
+	deadLetters       DeadLetterRepository
+	applications      ApplicationRepository
+	secretGracePeriod time.Duration
+	replayRate        float64
+	idempotencyTTL    time.Duration
 }
 
 type WebhookRepository interface {
-	SaveWebhook(ctx context.Context, url string, secret string) (int64, error)
+	SaveWebhook(ctx context.Context, url string, secret string, apiKeyHash string, applicationID int64) (int64, error)
 	GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error)
+	ListWebhooks(ctx context.Context, afterID int64, limit int) ([]models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID int64, update models.WebhookUpdate) (models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	PauseWebhook(ctx context.Context, webhookID int64) (models.Webhook, error)
+	ResumeWebhook(ctx context.Context, webhookID int64) (models.Webhook, error)
+	RotateWebhookSecret(ctx context.Context, webhookID int64, secret string, previousExpiresAt time.Time) (models.Webhook, error)
+	RotateWebhookAPIKey(ctx context.Context, webhookID int64, apiKeyHash string, previousExpiresAt time.Time) (models.Webhook, error)
 }
 
 type EventSaver interface {
-	SaveEventWithOutbox(ctx context.Context, webhookID int64, payload string) (int64, error)
+	SaveEventWithOutbox(ctx context.Context, webhookID int64, eventType string, payload string, idempotencyKey string, retention time.Duration) (eventID int64, created bool, err error)
+	SaveEventsWithOutbox(ctx context.Context, events []models.EventSubmission, retention time.Duration) ([]models.SubmissionResult, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	SaveOutboxEntries(ctx context.Context, entries []models.OutboxEntry) ([]int64, error)
 }
 
-func New(log *slog.Logger, webhookRepo WebhookRepository, eventSaver EventSaver) *Service {
-	return &Service{log: log, webhookRepo: webhookRepo, eventSaver: eventSaver}
+type EventProvider interface {
+	GetEvent(ctx context.Context, eventID int64) (models.RawEvent, error)
+	ListEvents(ctx context.Context, filter models.EventFilter, beforeID int64, limit int) ([]models.RawEvent, error)
+	ListEventsAfter(ctx context.Context, filter models.EventFilter, afterID int64, limit int) ([]models.RawEvent, error)
+	ListDeliveryAttempts(ctx context.Context, eventID int64, afterID int64, limit int) ([]models.DeliveryAttempt, error)
 }
 
-func (s *Service) CreateWebhook(ctx context.Context, url string) (webhookID int64, secret string, err error) {
-	secretBytes := make([]byte, 32)
-	_, err = rand.Read(secretBytes)
+type DeadLetterRepository interface {
+	GetDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error)
+	ListDeadLetters(ctx context.Context, filter models.DeadLetterFilter, beforeID int64, limit int) ([]models.DeadLetter, error)
+	RequeueDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error)
+	PurgeDeadLetters(ctx context.Context, filter models.DeadLetterFilter) (int64, error)
+}
+
+type ApplicationRepository interface {
+	SaveApplication(ctx context.Context, name string, apiKeyHash string) (int64, error)
+	GetApplication(ctx context.Context, applicationID int64) (models.Application, error)
+	DeleteApplication(ctx context.Context, applicationID int64) error
+	SaveMessageWithOutbox(ctx context.Context, applicationID int64, eventType string, payload string) (models.Message, []models.RawEvent, error)
+	GetMessage(ctx context.Context, messageID int64) (models.Message, error)
+}
+
+// New creates the service. secretGracePeriod is how long a rotated-out secret or
+// API key stays valid when rotated without an explicit grace period. replayRate
+// is the default number of replayed events per second scheduled by ReplayEvents.
+// idempotencyTTL is how long an idempotency key given to SubmitEvent keeps
+// resolving to the event it created.
+func New(log *slog.Logger, webhookRepo WebhookRepository, eventSaver EventSaver, eventProvider EventProvider, deadLetters DeadLetterRepository, applications ApplicationRepository, secretGracePeriod time.Duration, replayRate float64, idempotencyTTL time.Duration) *Service {
+	return &Service{
+		log:               log,
+		webhookRepo:       webhookRepo,
+		eventSaver:        eventSaver,
+		eventProvider:     eventProvider,
+		deadLetters:       deadLetters,
+		applications:      applications,
+		secretGracePeriod: secretGracePeriod,
+		replayRate:        replayRate,
+		idempotencyTTL:    idempotencyTTL,
+	}
+}
+
+// CreateWebhook registers a webhook and returns its delivery signing secret,
+// for the receiver, and its API key, for publishers. When applicationID is
+// positive the webhook also receives the messages published to that
+// application.
+func (s *Service) CreateWebhook(ctx context.Context, url string, applicationID int64) (webhookID int64, secret string, apiKey string, err error) {
+	secret, err = generateSigningSecret()
 	if err != nil {
 		s.log.Error("failed to generate secret", "error", err)
-		return 0, "", err
+		return 0, "", "", err
 	}
-	secret = hex.EncodeToString(secretBytes)
 
-	webhookID, err = s.webhookRepo.SaveWebhook(ctx, url, secret)
+	apiKey, err = generateSecret()
 	if err != nil {
+		s.log.Error("failed to generate api key", "error", err)
+		return 0, "", "", err
+	}
+
+	webhookID, err = s.webhookRepo.SaveWebhook(ctx, url, secret, hashAPIKey(apiKey), applicationID)
+	if err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return 0, "", "", models.ErrApplicationNotFound
+		}
 		s.log.Error("failed to save webhook", "error", err)
-		return 0, "", err
+		return 0, "", "", err
+	}
+
+	return webhookID, secret, apiKey, nil
+}
+
+func (s *Service) GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks returns up to limit webhooks with IDs greater than afterID, ordered by ID.
+func (s *Service) ListWebhooks(ctx context.Context, afterID int64, limit int) ([]models.Webhook, error) {
+	webhooks, err := s.webhookRepo.ListWebhooks(ctx, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Service) UpdateWebhook(ctx context.Context, webhookID int64, update models.WebhookUpdate) (models.Webhook, error) {
+	webhook, err := s.webhookRepo.UpdateWebhook(ctx, webhookID, update)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook removes the webhook, its events and any pending outbox entries.
+func (s *Service) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	if err := s.webhookRepo.DeleteWebhook(ctx, webhookID); err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return models.ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// PauseWebhook stops deliveries to the webhook. Events keep being accepted and
+// their deliveries are held until the webhook is resumed.
+func (s *Service) PauseWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	webhook, err := s.webhookRepo.PauseWebhook(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to pause webhook: %w", err)
+	}
+
+	s.log.Info("webhook paused", "webhook_id", webhookID)
+
+	return webhook, nil
+}
+
+// ResumeWebhook restarts deliveries to a paused or disabled webhook and
+// releases its held deliveries in the order they were queued. It is the way
+// to re-enable a webhook disabled by its circuit breaker or a 410 Gone
+// response once its endpoint is fixed.
+func (s *Service) ResumeWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	webhook, err := s.webhookRepo.ResumeWebhook(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to resume webhook: %w", err)
+	}
+
+	s.log.Info("webhook resumed", "webhook_id", webhookID)
+
+	return webhook, nil
+}
+
+// RotateWebhookSecret issues a new delivery signing secret for the webhook. The
+// old secret keeps signing deliveries for gracePeriod, or for the service
+// default when gracePeriod is nil.
+func (s *Service) RotateWebhookSecret(ctx context.Context, webhookID int64, gracePeriod *time.Duration) (secret string, previousExpiresAt time.Time, err error) {
+	secret, err = generateSigningSecret()
+	if err != nil {
+		s.log.Error("failed to generate secret", "error", err)
+		return "", time.Time{}, err
+	}
+
+	webhook, err := s.webhookRepo.RotateWebhookSecret(ctx, webhookID, secret, s.graceDeadline(gracePeriod))
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return "", time.Time{}, models.ErrWebhookNotFound
+		}
+		return "", time.Time{}, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+
+	s.log.Info("webhook secret rotated", "webhook_id", webhookID, "previous_secret_expires_at", *webhook.PreviousSecretExpiresAt)
+
+	return webhook.Secret, *webhook.PreviousSecretExpiresAt, nil
+}
+
+// RotateWebhookAPIKey issues a new publisher API key for the webhook. The old
+// key is still accepted by SubmitEvent for gracePeriod, or for the service
+// default when gracePeriod is nil.
+func (s *Service) RotateWebhookAPIKey(ctx context.Context, webhookID int64, gracePeriod *time.Duration) (apiKey string, previousExpiresAt time.Time, err error) {
+	apiKey, err = generateSecret()
+	if err != nil {
+		s.log.Error("failed to generate api key", "error", err)
+		return "", time.Time{}, err
+	}
+
+	webhook, err := s.webhookRepo.RotateWebhookAPIKey(ctx, webhookID, hashAPIKey(apiKey), s.graceDeadline(gracePeriod))
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return "", time.Time{}, models.ErrWebhookNotFound
+		}
+		return "", time.Time{}, fmt.Errorf("failed to rotate webhook api key: %w", err)
 	}
 
-	return webhookID, secret, nil
+	s.log.Info("webhook api key rotated", "webhook_id", webhookID, "previous_api_key_expires_at", *webhook.PreviousAPIKeyExpiresAt)
+
+	return apiKey, *webhook.PreviousAPIKeyExpiresAt, nil
+}
+
+func (s *Service) graceDeadline(gracePeriod *time.Duration) time.Time {
+	grace := s.secretGracePeriod
+	if gracePeriod != nil {
+		grace = *gracePeriod
+	}
+	return time.Now().Add(grace)
 }
 
-func (s *Service) SubmitEvent(ctx context.Context, webhookID int64, payload string, secret string) (eventID int64, err error) {
+// SubmitEvent stores an event for delivery. When idempotencyKey is not empty
+// and was already used for the webhook within the retention window, the
+// original event's ID is returned with created set to false and nothing is
+// stored.
+func (s *Service) SubmitEvent(ctx context.Context, webhookID int64, eventType string, payload string, apiKey string, idempotencyKey string) (eventID int64, created bool, err error) {
 	webhook, err := s.webhookRepo.GetWebhook(ctx, webhookID)
 	if err != nil {
 		if errors.Is(err, models.ErrWebhookNotFound) {
-			return 0, models.ErrWebhookNotFound
+			return 0, false, models.ErrWebhookNotFound
+		}
+		return 0, false, fmt.Errorf("failed to verify webhook existence: %w", err)
+	}
+
+	if err := s.authorizeSubmission(webhook, apiKey, time.Now()); err != nil {
+		return 0, false, err
+	}
+
+	if !json.Valid([]byte(payload)) {
+		return 0, false, ErrInvalidPayload
+	}
+
+	eventID, created, err = s.eventSaver.SaveEventWithOutbox(ctx, webhookID, eventType, payload, idempotencyKey, s.idempotencyTTL)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to save event: %w", err)
+	}
+	if !created {
+		s.log.Info("duplicate submission, returning original event", "event_id", eventID, "webhook_id", webhookID)
+	}
+
+	return eventID, created, nil
+}
+
+// authorizeSubmission checks that apiKey may submit events to the webhook.
+// Submissions with the previous API key are logged so publishers that have
+// not switched to the rotated key can be found before its grace period ends.
+func (s *Service) authorizeSubmission(webhook models.Webhook, apiKey string, now time.Time) error {
+	if !apiKeyMatches([]string{webhook.APIKeyHash}, apiKey) {
+		if !apiKeyMatches(webhook.ValidAPIKeyHashes(now)[1:], apiKey) {
+			return ErrInvalidAPIKey
+		}
+		s.log.Warn("event submitted with the previous api key", "webhook_id", webhook.ID, "previous_api_key_expires_at", *webhook.PreviousAPIKeyExpiresAt)
+	}
+
+	if webhook.Status == models.WebhookStatusDisabled {
+		return ErrWebhookDisabled
+	}
+
+	return nil
+}
+
+// RunIdempotencyKeyCleanup deletes expired idempotency keys every interval
+// until ctx is done. Expired keys are already reusable; this only keeps the
+// table small.
+func (s *Service) RunIdempotencyKeyCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.eventSaver.DeleteExpiredIdempotencyKeys(ctx)
+		if err != nil {
+			s.log.Error("failed to delete expired idempotency keys", "error", err)
+			continue
+		}
+		if n > 0 {
+			s.log.Info("deleted expired idempotency keys", "count", n)
 		}
-		return 0, fmt.Errorf("failed to verify webhook existence: %w", err)
 	}
+}
+
+func (s *Service) GetEvent(ctx context.Context, eventID int64) (models.RawEvent, error) {
+	event, err := s.eventProvider.GetEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, models.ErrEventNotFound) {
+			return models.RawEvent{}, models.ErrEventNotFound
+		}
+		return models.RawEvent{}, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return event, nil
+}
 
-	if webhook.Secret != secret {
-		return 0, ErrInvalidWebhookSecret
+// ListEvents returns up to limit events matching the filter, newest first,
+// starting below beforeID when it is positive.
+func (s *Service) ListEvents(ctx context.Context, filter models.EventFilter, beforeID int64, limit int) ([]models.RawEvent, error) {
+	events, err := s.eventProvider.ListEvents(ctx, filter, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
 
-	eventID, err = s.eventSaver.SaveEventWithOutbox(ctx, webhookID, payload)
+	return events, nil
+}
+
+// ListDeliveryAttempts returns up to limit delivery attempts for the event with
+// IDs greater than afterID, oldest first.
+func (s *Service) ListDeliveryAttempts(ctx context.Context, eventID int64, afterID int64, limit int) ([]models.DeliveryAttempt, error) {
+	attempts, err := s.eventProvider.ListDeliveryAttempts(ctx, eventID, afterID, limit)
 	if err != nil {
-		return 0, fmt.Errorf("failed to save event: %w", err)
+		return nil, fmt.Errorf("failed to list delivery attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+func generateSecret() (string, error) {
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secretBytes), nil
+}
+
+// generateSigningSecret returns a delivery signing secret in the Standard
+// Webhooks format: "whsec_" followed by 32 random bytes in base64.
+func generateSigningSecret() (string, error) {
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", err
 	}
+	return "whsec_" + base64.StdEncoding.EncodeToString(secretBytes), nil
+}
 
-	return eventID, nil
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func apiKeyMatches(validHashes []string, apiKey string) bool {
+	hash := []byte(hashAPIKey(apiKey))
+	for _, v := range validHashes {
+		if subtle.ConstantTimeCompare([]byte(v), hash) == 1 {
+			return true
+		}
+	}
+	return false
 }
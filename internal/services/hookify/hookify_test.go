@@ -2,52 +2,261 @@ package hookify
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"io"
 	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"hookify/internal/models"
 )
 
 type webhookRepoMock struct {
-	saveURL    string
-	saveSecret string
-	saveID     int64
-	saveErr    error
+	saveURL           string
+	saveSecret        string
+	saveKey           string
+	saveApplicationID int64
+	saveID            int64
+	saveErr           error
 
 	getWebhook models.Webhook
 	getErr     error
+	getCalls   int
+	webhooks   map[int64]models.Webhook
+
+	listAfterID int64
+	listLimit   int
+	listResult  []models.Webhook
+	listErr     error
+
+	updateID     int64
+	updateFields models.WebhookUpdate
+	updateResult models.Webhook
+	updateErr    error
+
+	deleteID  int64
+	deleteErr error
+
+	pausedID  int64
+	resumedID int64
+	statusErr error
+
+	rotateSecret    string
+	rotateExpiresAt time.Time
+	rotateErr       error
+
+	rotateKeyHash string
 }
 
-func (m *webhookRepoMock) SaveWebhook(ctx context.Context, url string, secret string) (int64, error) {
+func (m *webhookRepoMock) SaveWebhook(ctx context.Context, url string, secret string, apiKeyHash string, applicationID int64) (int64, error) {
 	m.saveURL = url
+	m.saveApplicationID = applicationID
 	m.saveSecret = secret
+	m.saveKey = apiKeyHash
 	return m.saveID, m.saveErr
 }
 
 func (m *webhookRepoMock) GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	m.getCalls++
+	if m.webhooks != nil {
+		w, ok := m.webhooks[webhookID]
+		if !ok {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return w, nil
+	}
 	return m.getWebhook, m.getErr
 }
 
+func (m *webhookRepoMock) ListWebhooks(ctx context.Context, afterID int64, limit int) ([]models.Webhook, error) {
+	m.listAfterID = afterID
+	m.listLimit = limit
+	return m.listResult, m.listErr
+}
+
+func (m *webhookRepoMock) UpdateWebhook(ctx context.Context, webhookID int64, update models.WebhookUpdate) (models.Webhook, error) {
+	m.updateID = webhookID
+	m.updateFields = update
+	return m.updateResult, m.updateErr
+}
+
+func (m *webhookRepoMock) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	m.deleteID = webhookID
+	return m.deleteErr
+}
+
+func (m *webhookRepoMock) PauseWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	m.pausedID = webhookID
+	if m.statusErr != nil {
+		return models.Webhook{}, m.statusErr
+	}
+	return models.Webhook{ID: webhookID, Status: models.WebhookStatusPaused}, nil
+}
+
+func (m *webhookRepoMock) ResumeWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	m.resumedID = webhookID
+	if m.statusErr != nil {
+		return models.Webhook{}, m.statusErr
+	}
+	return models.Webhook{ID: webhookID, Status: models.WebhookStatusActive}, nil
+}
+
+func (m *webhookRepoMock) RotateWebhookSecret(ctx context.Context, webhookID int64, secret string, previousExpiresAt time.Time) (models.Webhook, error) {
+	m.rotateSecret = secret
+	m.rotateExpiresAt = previousExpiresAt
+	if m.rotateErr != nil {
+		return models.Webhook{}, m.rotateErr
+	}
+	return models.Webhook{ID: webhookID, Secret: secret, PreviousSecret: "old", PreviousSecretExpiresAt: &previousExpiresAt}, nil
+}
+
+func (m *webhookRepoMock) RotateWebhookAPIKey(ctx context.Context, webhookID int64, apiKeyHash string, previousExpiresAt time.Time) (models.Webhook, error) {
+	m.rotateKeyHash = apiKeyHash
+	return models.Webhook{ID: webhookID, APIKeyHash: apiKeyHash, PreviousAPIKeyHash: "old", PreviousAPIKeyExpiresAt: &previousExpiresAt}, nil
+}
+
 type eventSaverMock struct {
 	savedWebhookID int64
 	savedPayload   string
+	savedTTL       time.Duration
 	id             int64
 	err            error
+	keys           map[string]int64
+	batches        [][]models.EventSubmission
+
+	outboxEntries   []models.OutboxEntry
+	statuses        map[int64]models.EventStatus
+	queued          map[int64]bool
+	scheduleCalls   int
+	scheduleErr     error
+	scheduleErrCall int
 }
 
-func (m *eventSaverMock) SaveEventWithOutbox(ctx context.Context, webhookID int64, payload string) (int64, error) {
+func (m *eventSaverMock) SaveEventWithOutbox(ctx context.Context, webhookID int64, eventType string, payload string, idempotencyKey string, retention time.Duration) (int64, bool, error) {
+	if id, ok := m.keys[idempotencyKey]; ok {
+		return id, false, nil
+	}
 	m.savedWebhookID = webhookID
 	m.savedPayload = payload
-	return m.id, m.err
+	m.savedTTL = retention
+	if idempotencyKey != "" {
+		if m.keys == nil {
+			m.keys = make(map[string]int64)
+		}
+		m.keys[idempotencyKey] = m.id
+	}
+	return m.id, m.err == nil, m.err
+}
+
+func (m *eventSaverMock) SaveEventsWithOutbox(ctx context.Context, events []models.EventSubmission, retention time.Duration) ([]models.SubmissionResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.batches = append(m.batches, events)
+	results := make([]models.SubmissionResult, len(events))
+	for i := range events {
+		m.id++
+		results[i] = models.SubmissionResult{EventID: m.id, Created: true}
+	}
+	return results, nil
+}
+
+func (m *eventSaverMock) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *eventSaverMock) SaveOutboxEntries(ctx context.Context, entries []models.OutboxEntry) ([]int64, error) {
+	m.scheduleCalls++
+	if m.scheduleErr != nil && m.scheduleCalls == m.scheduleErrCall {
+		return nil, m.scheduleErr
+	}
+	if m.statuses == nil {
+		m.statuses = make(map[int64]models.EventStatus)
+	}
+
+	var ids []int64
+	for _, entry := range entries {
+		if m.queued[entry.EventID] {
+			continue
+		}
+		m.statuses[entry.EventID] = models.EventStatusPending
+		m.outboxEntries = append(m.outboxEntries, entry)
+		ids = append(ids, int64(len(m.outboxEntries)))
+	}
+	return ids, nil
+}
+
+type eventProviderMock struct {
+	getEvent models.RawEvent
+	getErr   error
+
+	listFilter   models.EventFilter
+	listBeforeID int64
+	listLimit    int
+	listResult   []models.RawEvent
+	listErr      error
+
+	attemptsEventID int64
+	attemptsResult  []models.DeliveryAttempt
+}
+
+func (m *eventProviderMock) GetEvent(ctx context.Context, eventID int64) (models.RawEvent, error) {
+	return m.getEvent, m.getErr
+}
+
+func (m *eventProviderMock) ListEvents(ctx context.Context, filter models.EventFilter, beforeID int64, limit int) ([]models.RawEvent, error) {
+	m.listFilter = filter
+	m.listBeforeID = beforeID
+	m.listLimit = limit
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+
+	var page []models.RawEvent
+	for _, e := range m.listResult {
+		if beforeID > 0 && e.ID >= beforeID {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, e)
+	}
+	return page, nil
+}
+
+func (m *eventProviderMock) ListEventsAfter(ctx context.Context, filter models.EventFilter, afterID int64, limit int) ([]models.RawEvent, error) {
+	m.listFilter = filter
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+
+	var page []models.RawEvent
+	for i := len(m.listResult) - 1; i >= 0 && len(page) < limit; i-- {
+		if e := m.listResult[i]; e.ID > afterID {
+			page = append(page, e)
+		}
+	}
+	return page, nil
+}
+
+func (m *eventProviderMock) ListDeliveryAttempts(ctx context.Context, eventID int64, afterID int64, limit int) ([]models.DeliveryAttempt, error) {
+	m.attemptsEventID = eventID
+	return m.attemptsResult, nil
+}
+
+func validSigningSecret(secret string) bool {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	return strings.HasPrefix(secret, "whsec_") && err == nil && len(key) == 32
 }
 
 func TestCreateWebhook_GeneratesSecretAndSaves(t *testing.T) {
 	repo := &webhookRepoMock{saveID: 123}
-	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{})
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
 
-	id, secret, err := svc.CreateWebhook(context.Background(), "https://example.com")
+	id, secret, apiKey, err := svc.CreateWebhook(context.Background(), "https://example.com", 0)
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -60,40 +269,46 @@ func TestCreateWebhook_GeneratesSecretAndSaves(t *testing.T) {
 	if secret == "" {
 		t.Fatalf("expected secret to be non-empty")
 	}
-	if len(secret) != 64 {
-		t.Fatalf("expected hex secret length=64, got %d", len(secret))
+	if !validSigningSecret(secret) {
+		t.Fatalf("expected a whsec_ secret of 32 base64 bytes, got %q", secret)
 	}
 	if repo.saveSecret != secret {
 		t.Fatalf("expected saved secret to match returned secret")
 	}
+	if apiKey == "" || apiKey == secret {
+		t.Fatalf("expected a separate non-empty api key")
+	}
+	if repo.saveKey != hashAPIKey(apiKey) {
+		t.Fatalf("expected only the api key hash to be saved")
+	}
 }
 
 func TestSubmitEvent_WebhookNotFound(t *testing.T) {
 	repo := &webhookRepoMock{getErr: models.ErrWebhookNotFound}
-	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{})
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
 
-	_, err := svc.SubmitEvent(context.Background(), 1, `{}`, "x")
+	_, _, err := svc.SubmitEvent(context.Background(), 1, "", `{}`, "x", "")
 	if !errors.Is(err, models.ErrWebhookNotFound) {
 		t.Fatalf("expected ErrWebhookNotFound, got %v", err)
 	}
 }
 
-func TestSubmitEvent_InvalidSecret(t *testing.T) {
-	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, URL: "https://example.com", Secret: "expected"}}
-	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{})
+func TestSubmitEvent_InvalidAPIKey(t *testing.T) {
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, URL: "https://example.com", APIKeyHash: hashAPIKey("expected")}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
 
-	_, err := svc.SubmitEvent(context.Background(), 1, `{}`, "wrong")
-	if !errors.Is(err, ErrInvalidWebhookSecret) {
-		t.Fatalf("expected ErrInvalidWebhookSecret, got %v", err)
+	_, _, err := svc.SubmitEvent(context.Background(), 1, "", `{}`, "wrong", "")
+	if !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("expected ErrInvalidAPIKey, got %v", err)
 	}
 }
 
 func TestSubmitEvent_PublishesPendingEvent(t *testing.T) {
-	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 7, URL: "https://example.com", Secret: "s"}}
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 7, URL: "https://example.com", APIKeyHash: hashAPIKey("s")}}
 	saver := &eventSaverMock{id: 99}
-	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, saver)
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, saver, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
 
-	id, err := svc.SubmitEvent(context.Background(), 7, `{"a":1}`, "s")
+	id, _, err := svc.SubmitEvent(context.Background(), 7, "", `{"a":1}`, "s", "")
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -101,3 +316,538 @@ func TestSubmitEvent_PublishesPendingEvent(t *testing.T) {
 		t.Fatalf("expected event id=99, got %d", id)
 	}
 }
+
+func TestListWebhooks_PassesCursor(t *testing.T) {
+	repo := &webhookRepoMock{listResult: []models.Webhook{{ID: 11}, {ID: 12}}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	webhooks, err := svc.ListWebhooks(context.Background(), 10, 2)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(webhooks) != 2 {
+		t.Fatalf("expected 2 webhooks, got %d", len(webhooks))
+	}
+	if repo.listAfterID != 10 || repo.listLimit != 2 {
+		t.Fatalf("unexpected args: afterID=%d limit=%d", repo.listAfterID, repo.listLimit)
+	}
+}
+
+func TestUpdateWebhook_NotFound(t *testing.T) {
+	repo := &webhookRepoMock{updateErr: models.ErrWebhookNotFound}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	url := "https://example.com/new"
+	_, err := svc.UpdateWebhook(context.Background(), 3, models.WebhookUpdate{URL: &url})
+	if !errors.Is(err, models.ErrWebhookNotFound) {
+		t.Fatalf("expected ErrWebhookNotFound, got %v", err)
+	}
+	if repo.updateID != 3 || repo.updateFields.URL == nil || *repo.updateFields.URL != url {
+		t.Fatalf("unexpected update args: id=%d update=%#v", repo.updateID, repo.updateFields)
+	}
+}
+
+func TestDeleteWebhook_WrapsStorageError(t *testing.T) {
+	storageErr := errors.New("boom")
+	repo := &webhookRepoMock{deleteErr: storageErr}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	err := svc.DeleteWebhook(context.Background(), 5)
+	if !errors.Is(err, storageErr) {
+		t.Fatalf("expected wrapped storage error, got %v", err)
+	}
+	if repo.deleteID != 5 {
+		t.Fatalf("expected deleteID=5, got %d", repo.deleteID)
+	}
+}
+
+func TestRotateWebhookSecret_UsesDefaultGracePeriod(t *testing.T) {
+	repo := &webhookRepoMock{}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	before := time.Now()
+	secret, expiresAt, err := svc.RotateWebhookSecret(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if !validSigningSecret(secret) || secret != repo.rotateSecret {
+		t.Fatalf("expected new whsec_ secret to be saved, got %q", secret)
+	}
+	if expiresAt.Before(before.Add(time.Hour)) || expiresAt.After(time.Now().Add(time.Hour)) {
+		t.Fatalf("expected expiry about one hour from now, got %v", expiresAt)
+	}
+}
+
+func TestRotateWebhookSecret_ExplicitGracePeriod(t *testing.T) {
+	repo := &webhookRepoMock{}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	grace := time.Duration(0)
+	_, expiresAt, err := svc.RotateWebhookSecret(context.Background(), 1, &grace)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if expiresAt.After(time.Now()) {
+		t.Fatalf("expected previous secret to expire immediately, got %v", expiresAt)
+	}
+}
+
+func TestSubmitEvent_AcceptsPreviousAPIKeyDuringGracePeriod(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute)
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, APIKeyHash: hashAPIKey("new"), PreviousAPIKeyHash: hashAPIKey("old"), PreviousAPIKeyExpiresAt: &expiresAt}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{id: 1}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	if _, _, err := svc.SubmitEvent(context.Background(), 1, "", `{}`, "old", ""); err != nil {
+		t.Fatalf("expected previous api key to be accepted, got %v", err)
+	}
+}
+
+func TestSubmitEvent_RejectsExpiredPreviousAPIKey(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Minute)
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, APIKeyHash: hashAPIKey("new"), PreviousAPIKeyHash: hashAPIKey("old"), PreviousAPIKeyExpiresAt: &expiresAt}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{id: 1}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, _, err := svc.SubmitEvent(context.Background(), 1, "", `{}`, "old", "")
+	if !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("expected ErrInvalidAPIKey, got %v", err)
+	}
+}
+
+func TestSubmitEvent_RejectsSigningSecret(t *testing.T) {
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, Secret: "signing", APIKeyHash: hashAPIKey("key")}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{id: 1}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, _, err := svc.SubmitEvent(context.Background(), 1, "", `{}`, "signing", "")
+	if !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("expected ErrInvalidAPIKey, got %v", err)
+	}
+}
+
+func TestRotateWebhookAPIKey_SavesHash(t *testing.T) {
+	repo := &webhookRepoMock{}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	apiKey, _, err := svc.RotateWebhookAPIKey(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if repo.rotateKeyHash != hashAPIKey(apiKey) {
+		t.Fatalf("expected hash of the new api key to be saved")
+	}
+}
+
+func TestGetEvent_NotFound(t *testing.T) {
+	events := &eventProviderMock{getErr: models.ErrEventNotFound}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, events, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, err := svc.GetEvent(context.Background(), 1)
+	if !errors.Is(err, models.ErrEventNotFound) {
+		t.Fatalf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestListEvents_PassesFilter(t *testing.T) {
+	events := &eventProviderMock{listResult: []models.RawEvent{{ID: 3}}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, events, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	filter := models.EventFilter{WebhookID: 7, Status: models.EventStatusFailed}
+	got, err := svc.ListEvents(context.Background(), filter, 10, 5)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	if events.listFilter != filter || events.listBeforeID != 10 || events.listLimit != 5 {
+		t.Fatalf("unexpected args: filter=%#v beforeID=%d limit=%d", events.listFilter, events.listBeforeID, events.listLimit)
+	}
+}
+
+func TestRedeliverEvent_QueuesDelivery(t *testing.T) {
+	saver := &eventSaverMock{}
+	events := &eventProviderMock{getEvent: models.RawEvent{ID: 4, WebhookID: 2, Payload: `{"a":1}`, Status: models.EventStatusFailed}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, saver, events, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	if err := svc.RedeliverEvent(context.Background(), 4); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if saver.statuses[4] != models.EventStatusPending {
+		t.Fatalf("expected event status reset to pending, got %q", saver.statuses[4])
+	}
+	if len(saver.outboxEntries) != 1 {
+		t.Fatalf("expected 1 outbox entry, got %d", len(saver.outboxEntries))
+	}
+	entry := saver.outboxEntries[0]
+	if entry.Type != models.OutboxTypeDelivery || entry.EventID != 4 {
+		t.Fatalf("unexpected outbox entry: %#v", entry)
+	}
+}
+
+func TestRedeliverEvent_NotFound(t *testing.T) {
+	events := &eventProviderMock{getErr: models.ErrEventNotFound}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, events, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	if err := svc.RedeliverEvent(context.Background(), 4); !errors.Is(err, models.ErrEventNotFound) {
+		t.Fatalf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestReplayEvents_OldestFirstAndRateLimited(t *testing.T) {
+	saver := &eventSaverMock{}
+	var all []models.RawEvent
+	for id := int64(replayPageSize + 5); id > 0; id-- {
+		all = append(all, models.RawEvent{ID: id, WebhookID: 1})
+	}
+	events := &eventProviderMock{listResult: all}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, saver, events, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	scheduled, err := svc.ReplayEvents(context.Background(), models.EventFilter{WebhookID: 1, Status: models.EventStatusFailed}, 2)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if scheduled != len(all) || len(saver.outboxEntries) != len(all) {
+		t.Fatalf("expected %d scheduled entries, got %d (%d saved)", len(all), scheduled, len(saver.outboxEntries))
+	}
+
+	first, second := saver.outboxEntries[0], saver.outboxEntries[1]
+	if first.EventID != 1 || second.EventID != 2 {
+		t.Fatalf("expected oldest events first, got %d then %d", first.EventID, second.EventID)
+	}
+	if gap := second.NextAttemptAt.Sub(first.NextAttemptAt); gap != 500*time.Millisecond {
+		t.Fatalf("expected 500ms between deliveries at 2/s, got %v", gap)
+	}
+	if saver.scheduleCalls != 2 {
+		t.Fatalf("expected one transaction per page, got %d", saver.scheduleCalls)
+	}
+	last, next := saver.outboxEntries[replayPageSize-1], saver.outboxEntries[replayPageSize]
+	if gap := next.NextAttemptAt.Sub(last.NextAttemptAt); gap != 500*time.Millisecond {
+		t.Fatalf("expected the next page to continue the schedule, got a %v gap", gap)
+	}
+}
+
+func TestReplayEvents_SkipsQueuedEvents(t *testing.T) {
+	saver := &eventSaverMock{queued: map[int64]bool{2: true}}
+	events := &eventProviderMock{listResult: []models.RawEvent{{ID: 3}, {ID: 2}, {ID: 1}}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, saver, events, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	scheduled, err := svc.ReplayEvents(context.Background(), models.EventFilter{WebhookID: 1}, 0)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if scheduled != 2 || saver.outboxEntries[0].EventID != 1 || saver.outboxEntries[1].EventID != 3 {
+		t.Fatalf("expected events 1 and 3 to be queued, got %d: %#v", scheduled, saver.outboxEntries)
+	}
+}
+
+func TestReplayEvents_ReportsScheduledOnFailure(t *testing.T) {
+	saver := &eventSaverMock{scheduleErr: errors.New("db down"), scheduleErrCall: 2}
+	var all []models.RawEvent
+	for id := int64(replayPageSize + 5); id > 0; id-- {
+		all = append(all, models.RawEvent{ID: id, WebhookID: 1})
+	}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, saver, &eventProviderMock{listResult: all}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	scheduled, err := svc.ReplayEvents(context.Background(), models.EventFilter{WebhookID: 1}, 0)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if scheduled != replayPageSize {
+		t.Fatalf("expected the first page to be reported as scheduled, got %d", scheduled)
+	}
+}
+
+func TestSubmitEvent_WebhookDisabled(t *testing.T) {
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, APIKeyHash: hashAPIKey("key"), Status: models.WebhookStatusDisabled}}
+	saver := &eventSaverMock{id: 1}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, saver, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, _, err := svc.SubmitEvent(context.Background(), 1, "", `{}`, "key", "")
+	if !errors.Is(err, ErrWebhookDisabled) {
+		t.Fatalf("expected ErrWebhookDisabled, got %v", err)
+	}
+}
+
+func TestPauseWebhook_OK(t *testing.T) {
+	repo := &webhookRepoMock{}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	webhook, err := svc.PauseWebhook(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if repo.pausedID != 4 || webhook.Status != models.WebhookStatusPaused {
+		t.Fatalf("unexpected pause: id=%d webhook=%#v", repo.pausedID, webhook)
+	}
+}
+
+func TestResumeWebhook_NotFound(t *testing.T) {
+	repo := &webhookRepoMock{statusErr: models.ErrWebhookNotFound}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, err := svc.ResumeWebhook(context.Background(), 4)
+	if !errors.Is(err, models.ErrWebhookNotFound) {
+		t.Fatalf("expected ErrWebhookNotFound, got %v", err)
+	}
+}
+
+func TestResumeWebhook_ReenablesDisabledWebhook(t *testing.T) {
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 4, Status: models.WebhookStatusDisabled}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	webhook, err := svc.ResumeWebhook(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if repo.resumedID != 4 || webhook.Status != models.WebhookStatusActive {
+		t.Fatalf("unexpected resume: id=%d webhook=%#v", repo.resumedID, webhook)
+	}
+}
+
+func TestSubmitEvent_RepeatedIdempotencyKeyReturnsOriginalEvent(t *testing.T) {
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 7, APIKeyHash: hashAPIKey("s")}}
+	saver := &eventSaverMock{id: 99}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, saver, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, 2*time.Hour)
+
+	id, created, err := svc.SubmitEvent(context.Background(), 7, "", `{"a":1}`, "s", "order-1")
+	if err != nil || id != 99 || !created {
+		t.Fatalf("expected event 99 to be created, got id=%d created=%v err=%v", id, created, err)
+	}
+	if saver.savedTTL != 2*time.Hour {
+		t.Fatalf("expected retention to be passed, got %v", saver.savedTTL)
+	}
+
+	saver.id = 100
+	id, created, err = svc.SubmitEvent(context.Background(), 7, "", `{"a":1}`, "s", "order-1")
+	if err != nil || id != 99 || created {
+		t.Fatalf("expected original event 99, got id=%d created=%v err=%v", id, created, err)
+	}
+}
+
+func TestSubmitEvent_InvalidPayload(t *testing.T) {
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, APIKeyHash: hashAPIKey("key")}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, _, err := svc.SubmitEvent(context.Background(), 1, "", `{"a":`, "key", "")
+	if !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("expected ErrInvalidPayload, got %v", err)
+	}
+}
+
+func TestSubmitEvents_RejectsItemsIndividually(t *testing.T) {
+	repo := &webhookRepoMock{webhooks: map[int64]models.Webhook{
+		1: {ID: 1, APIKeyHash: hashAPIKey("key")},
+		2: {ID: 2, APIKeyHash: hashAPIKey("key"), Status: models.WebhookStatusDisabled},
+	}}
+	saver := &eventSaverMock{id: 10}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, saver, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	results, err := svc.SubmitEvents(context.Background(), []models.EventSubmission{
+		{WebhookID: 1, Payload: `{"n":1}`, APIKey: "key"},
+		{WebhookID: 1, Payload: `{"n":`, APIKey: "key"},
+		{WebhookID: 1, Payload: `{}`, APIKey: "wrong"},
+		{WebhookID: 2, Payload: `{}`, APIKey: "key"},
+		{WebhookID: 3, Payload: `{}`, APIKey: "key"},
+		{WebhookID: 1, Payload: `{"n":2}`, APIKey: "key"},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	wantErrs := []error{nil, ErrInvalidPayload, ErrInvalidAPIKey, ErrWebhookDisabled, models.ErrWebhookNotFound, nil}
+	for i, want := range wantErrs {
+		if !errors.Is(results[i].Err, want) {
+			t.Fatalf("result %d: expected error %v, got %v", i, want, results[i].Err)
+		}
+	}
+	if results[0].EventID != 11 || !results[0].Created || results[5].EventID != 12 {
+		t.Fatalf("unexpected results for stored events: %#v", results)
+	}
+	if len(saver.batches) != 1 || len(saver.batches[0]) != 2 {
+		t.Fatalf("expected the two valid events to be saved in one batch, got %#v", saver.batches)
+	}
+	if repo.getCalls != 3 {
+		t.Fatalf("expected one lookup per webhook, got %d", repo.getCalls)
+	}
+}
+
+func TestSubmitEvent_AcceptedWhilePaused(t *testing.T) {
+	repo := &webhookRepoMock{getWebhook: models.Webhook{ID: 1, APIKeyHash: hashAPIKey("key"), Status: models.WebhookStatusPaused}}
+	saver := &eventSaverMock{id: 5}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), repo, saver, &eventProviderMock{}, &deadLetterRepoMock{}, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	id, _, err := svc.SubmitEvent(context.Background(), 1, "", `{}`, "key", "")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if id != 5 {
+		t.Fatalf("expected event id=5, got %d", id)
+	}
+}
+
+type deadLetterRepoMock struct {
+	getDeadLetter models.DeadLetter
+	getErr        error
+	requeuedID    int64
+	requeueErr    error
+	purgeFilter   models.DeadLetterFilter
+}
+
+func (m *deadLetterRepoMock) GetDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error) {
+	return m.getDeadLetter, m.getErr
+}
+
+func (m *deadLetterRepoMock) ListDeadLetters(ctx context.Context, filter models.DeadLetterFilter, beforeID int64, limit int) ([]models.DeadLetter, error) {
+	return nil, nil
+}
+
+func (m *deadLetterRepoMock) RequeueDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error) {
+	m.requeuedID = id
+	if m.requeueErr != nil {
+		return models.DeadLetter{}, m.requeueErr
+	}
+	return m.getDeadLetter, nil
+}
+
+func (m *deadLetterRepoMock) PurgeDeadLetters(ctx context.Context, filter models.DeadLetterFilter) (int64, error) {
+	m.purgeFilter = filter
+	return 3, nil
+}
+
+func TestRequeueDeadLetter_OK(t *testing.T) {
+	deadLetters := &deadLetterRepoMock{getDeadLetter: models.DeadLetter{ID: 7, Source: models.DeadLetterSourceOutbox, EventID: 10, WebhookID: 2, Type: models.OutboxTypeDelivery}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, &eventProviderMock{}, deadLetters, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	deadLetter, err := svc.RequeueDeadLetter(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if deadLetters.requeuedID != 7 || deadLetter.EventID != 10 {
+		t.Fatalf("unexpected requeue: id=%d dead letter=%#v", deadLetters.requeuedID, deadLetter)
+	}
+}
+
+func TestRequeueDeadLetter_UnattributedIsNotRequeueable(t *testing.T) {
+	deadLetters := &deadLetterRepoMock{getDeadLetter: models.DeadLetter{ID: 7, Source: models.DeadLetterSourceConsumer, Payload: "{"}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, &eventProviderMock{}, deadLetters, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, err := svc.RequeueDeadLetter(context.Background(), 7)
+	if !errors.Is(err, ErrDeadLetterNotRequeueable) {
+		t.Fatalf("expected ErrDeadLetterNotRequeueable, got %v", err)
+	}
+	if deadLetters.requeuedID != 0 {
+		t.Fatalf("expected nothing to be requeued")
+	}
+}
+
+func TestRequeueDeadLetter_NotFound(t *testing.T) {
+	deadLetters := &deadLetterRepoMock{getErr: models.ErrDeadLetterNotFound}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, &eventProviderMock{}, deadLetters, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, err := svc.RequeueDeadLetter(context.Background(), 7)
+	if !errors.Is(err, models.ErrDeadLetterNotFound) {
+		t.Fatalf("expected ErrDeadLetterNotFound, got %v", err)
+	}
+}
+
+func TestRequeueDeadLetter_WebhookDeleted(t *testing.T) {
+	deadLetters := &deadLetterRepoMock{
+		getDeadLetter: models.DeadLetter{ID: 7, Source: models.DeadLetterSourceOutbox, EventID: 10, WebhookID: 2, Type: models.OutboxTypeDelivery},
+		requeueErr:    models.ErrWebhookNotFound,
+	}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, &eventProviderMock{}, deadLetters, &applicationRepoMock{}, time.Hour, 10, time.Hour)
+
+	_, err := svc.RequeueDeadLetter(context.Background(), 7)
+	if !errors.Is(err, models.ErrWebhookNotFound) {
+		t.Fatalf("expected ErrWebhookNotFound, got %v", err)
+	}
+}
+
+type applicationRepoMock struct {
+	app       models.Application
+	getErr    error
+	savedName string
+	savedHash string
+
+	publishedType    string
+	publishedPayload string
+	deliveries       []models.RawEvent
+}
+
+func (m *applicationRepoMock) SaveApplication(ctx context.Context, name string, apiKeyHash string) (int64, error) {
+	m.savedName = name
+	m.savedHash = apiKeyHash
+	return 4, nil
+}
+
+func (m *applicationRepoMock) GetApplication(ctx context.Context, applicationID int64) (models.Application, error) {
+	return m.app, m.getErr
+}
+
+func (m *applicationRepoMock) DeleteApplication(ctx context.Context, applicationID int64) error {
+	return m.getErr
+}
+
+func (m *applicationRepoMock) SaveMessageWithOutbox(ctx context.Context, applicationID int64, eventType string, payload string) (models.Message, []models.RawEvent, error) {
+	m.publishedType = eventType
+	m.publishedPayload = payload
+	return models.Message{ID: 9, ApplicationID: applicationID, EventType: eventType, Payload: payload}, m.deliveries, nil
+}
+
+func (m *applicationRepoMock) GetMessage(ctx context.Context, messageID int64) (models.Message, error) {
+	return models.Message{}, models.ErrMessageNotFound
+}
+
+func TestCreateApplication_StoresKeyHash(t *testing.T) {
+	apps := &applicationRepoMock{}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, apps, time.Hour, 10, time.Hour)
+
+	id, apiKey, err := svc.CreateApplication(context.Background(), "billing")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if id != 4 || apiKey == "" || apps.savedName != "billing" || apps.savedHash != hashAPIKey(apiKey) {
+		t.Fatalf("unexpected result id=%d apiKey=%q saved=%q/%q", id, apiKey, apps.savedName, apps.savedHash)
+	}
+}
+
+func TestPublishMessage_ReturnsDeliveries(t *testing.T) {
+	apps := &applicationRepoMock{
+		app: models.Application{ID: 3, APIKeyHash: hashAPIKey("key")},
+		deliveries: []models.RawEvent{
+			{ID: 20, WebhookID: 1, MessageID: 9},
+			{ID: 21, WebhookID: 2, MessageID: 9},
+		},
+	}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, apps, time.Hour, 10, time.Hour)
+
+	message, deliveries, err := svc.PublishMessage(context.Background(), 3, "invoice.paid", `{"a":1}`, "key")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if message.ID != 9 || len(deliveries) != 2 {
+		t.Fatalf("unexpected message %#v with deliveries %#v", message, deliveries)
+	}
+	if apps.publishedType != "invoice.paid" || apps.publishedPayload != `{"a":1}` {
+		t.Fatalf("unexpected published message type=%q payload=%q", apps.publishedType, apps.publishedPayload)
+	}
+}
+
+func TestPublishMessage_Rejects(t *testing.T) {
+	apps := &applicationRepoMock{app: models.Application{ID: 3, APIKeyHash: hashAPIKey("key")}}
+	svc := New(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), &webhookRepoMock{}, &eventSaverMock{}, &eventProviderMock{}, &deadLetterRepoMock{}, apps, time.Hour, 10, time.Hour)
+
+	if _, _, err := svc.PublishMessage(context.Background(), 3, "", `{}`, "webhook-key"); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("expected ErrInvalidAPIKey, got %v", err)
+	}
+	if _, _, err := svc.PublishMessage(context.Background(), 3, "", `{`, "key"); !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("expected ErrInvalidPayload, got %v", err)
+	}
+	if apps.publishedPayload != "" {
+		t.Fatalf("expected nothing to be stored")
+	}
+
+	apps.getErr = models.ErrApplicationNotFound
+	if _, _, err := svc.PublishMessage(context.Background(), 3, "", `{}`, "key"); !errors.Is(err, models.ErrApplicationNotFound) {
+		t.Fatalf("expected ErrApplicationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package hookify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hookify/internal/models"
+	"time"
+)
+
+// replayPageSize is how many events are read and queued per transaction
+// during a replay.
+const replayPageSize = 500
+
+// RedeliverEvent resets the event to pending and queues an immediate delivery.
+// Nothing is queued while the event still has a delivery pending.
+func (s *Service) RedeliverEvent(ctx context.Context, eventID int64) error {
+	event, err := s.eventProvider.GetEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, models.ErrEventNotFound) {
+			return models.ErrEventNotFound
+		}
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	queued, err := s.eventSaver.SaveOutboxEntries(ctx, []models.OutboxEntry{deliveryEntry(event, time.Now())})
+	if err != nil {
+		return fmt.Errorf("failed to schedule delivery: %w", err)
+	}
+
+	if len(queued) == 0 {
+		s.log.Info("event already queued for delivery", "event_id", event.ID, "webhook_id", event.WebhookID)
+		return nil
+	}
+	s.log.Info("event queued for redelivery", "event_id", event.ID, "webhook_id", event.WebhookID)
+
+	return nil
+}
+
+// ReplayEvents queues a delivery for every event matching the filter, oldest
+// first, one page at a time. Deliveries are spread out at ratePerSecond, or at
+// the service default when ratePerSecond is not positive, so a recovering
+// endpoint is not flooded. Events that still have a delivery pending are
+// skipped, so a replay that stopped partway can simply be run again. The
+// number of events queued is returned even when the replay fails.
+func (s *Service) ReplayEvents(ctx context.Context, filter models.EventFilter, ratePerSecond float64) (scheduled int, err error) {
+	if ratePerSecond <= 0 {
+		ratePerSecond = s.replayRate
+	}
+	interval := time.Duration(float64(time.Second) / ratePerSecond)
+
+	start := time.Now()
+	var afterID int64
+	for {
+		page, err := s.eventProvider.ListEventsAfter(ctx, filter, afterID, replayPageSize)
+		if err != nil {
+			return scheduled, fmt.Errorf("failed to list events: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		// An event skipped because it is still queued leaves its slot unused.
+		entries := make([]models.OutboxEntry, len(page))
+		for i, event := range page {
+			entries[i] = deliveryEntry(event, start.Add(time.Duration(scheduled+i)*interval))
+		}
+		queued, err := s.eventSaver.SaveOutboxEntries(ctx, entries)
+		if err != nil {
+			return scheduled, fmt.Errorf("failed to schedule deliveries: %w", err)
+		}
+		scheduled += len(queued)
+
+		if len(page) < replayPageSize {
+			break
+		}
+		afterID = page[len(page)-1].ID
+	}
+
+	s.log.Info("events queued for replay", "webhook_id", filter.WebhookID, "count", scheduled, "rate_per_second", ratePerSecond)
+
+	return scheduled, nil
+}
+
+// deliveryEntry is a fresh delivery job for the event, due at nextAttemptAt.
+func deliveryEntry(event models.RawEvent, nextAttemptAt time.Time) models.OutboxEntry {
+	return models.OutboxEntry{
+		Type:          models.OutboxTypeDelivery,
+		EventID:       event.ID,
+		WebhookID:     event.WebhookID,
+		Payload:       event.Payload,
+		NextAttemptAt: nextAttemptAt,
+	}
+}
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hookify/internal/models"
+	"slices"
+
+	"github.com/lib/pq"
+)
+
+func (s *Storage) SaveApplication(ctx context.Context, name string, apiKeyHash string) (int64, error) {
+	var id int64
+	err := s.db.QueryRowContext(ctx, "INSERT INTO applications(name, api_key_hash) VALUES($1, $2) RETURNING id", name, apiKeyHash).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert application: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetApplication(ctx context.Context, applicationID int64) (models.Application, error) {
+	var app models.Application
+	err := s.db.QueryRowContext(ctx, "SELECT id, name, api_key_hash, created_at FROM applications WHERE id=$1", applicationID).
+		Scan(&app.ID, &app.Name, &app.APIKeyHash, &app.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Application{}, models.ErrApplicationNotFound
+		}
+		return models.Application{}, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return app, nil
+}
+
+// DeleteApplication removes the application together with the pending outbox
+// entries of its webhooks. Webhooks, messages and events are removed by the
+// foreign key cascade.
+func (s *Storage) DeleteApplication(ctx context.Context, applicationID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM outbox WHERE webhook_id IN (SELECT id FROM webhooks WHERE application_id=$1)", applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to delete outbox entries: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM applications WHERE id=$1", applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return models.ErrApplicationNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveMessageWithOutbox stores a message published to the application and, in
+// the same transaction, one pending event per webhook of the application that
+// is not disabled and is subscribed to the message's type, each with its own
+// publish outbox entry. It returns the message and those events.
+func (s *Storage) SaveMessageWithOutbox(ctx context.Context, applicationID int64, eventType string, payload string) (models.Message, []models.RawEvent, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Message{}, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	message := models.Message{ApplicationID: applicationID, EventType: eventType, Payload: payload}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO messages(application_id, event_type, payload)
+		SELECT $1, $2, $3::jsonb
+		WHERE EXISTS (SELECT 1 FROM applications WHERE id = $1)
+		RETURNING id, created_at`, applicationID, eventType, payload).Scan(&message.ID, &message.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Message{}, nil, models.ErrApplicationNotFound
+		}
+		return models.Message{}, nil, fmt.Errorf("failed to insert message: %w", err)
+	}
+
+	webhookIDs, err := subscribedWebhooks(ctx, tx, applicationID, eventType)
+	if err != nil {
+		return models.Message{}, nil, err
+	}
+
+	var events []models.RawEvent
+	if len(webhookIDs) > 0 {
+		events, err = insertMessageEvents(ctx, tx, message, webhookIDs)
+		if err != nil {
+			return models.Message{}, nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO outbox(event_id, webhook_id, event_type, payload, attempts, next_attempt_at, type)
+			SELECT id, webhook_id, event_type, payload, 0, NOW(), $2 FROM events WHERE message_id = $1 ORDER BY id`,
+			message.ID, models.OutboxTypePublish)
+		if err != nil {
+			return models.Message{}, nil, fmt.Errorf("failed to insert outbox entries: %w", err)
+		}
+
+		// Delivered to listeners once the transaction commits.
+		if err := notifyOutbox(ctx, tx); err != nil {
+			return models.Message{}, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Message{}, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return message, events, nil
+}
+
+// subscribedWebhooks locks the application's webhooks that are not disabled,
+// in ID order, and returns those subscribed to eventType.
+func subscribedWebhooks(ctx context.Context, tx *sql.Tx, applicationID int64, eventType string) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE application_id=$1 AND status<>$2 ORDER BY id FOR UPDATE",
+		applicationID, models.WebhookStatusDisabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		if webhook.SubscribesTo(eventType) {
+			ids = append(ids, webhook.ID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhooks: %w", err)
+	}
+
+	return ids, nil
+}
+
+// insertMessageEvents stores one pending event of the message per webhook,
+// each under the webhook's next sequence number.
+func insertMessageEvents(ctx context.Context, tx *sql.Tx, message models.Message, webhookIDs []int64) ([]models.RawEvent, error) {
+	rows, err := tx.QueryContext(ctx, `
+		WITH seq AS (
+			UPDATE webhooks SET last_sequence = last_sequence + 1 WHERE id = ANY($1) RETURNING id, last_sequence)
+		INSERT INTO events(webhook_id, message_id, event_type, payload, status, sequence)
+		SELECT id, $2, $3, $4::jsonb, $5::event_status, last_sequence FROM seq ORDER BY id
+		RETURNING `+eventColumns,
+		pq.Array(webhookIDs), message.ID, message.EventType, message.Payload, models.EventStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.RawEvent
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate events: %w", err)
+	}
+
+	// RETURNING does not preserve the order of the SELECT.
+	slices.SortFunc(events, func(a, b models.RawEvent) int { return cmp.Compare(a.ID, b.ID) })
+
+	return events, nil
+}
+
+func (s *Storage) GetMessage(ctx context.Context, messageID int64) (models.Message, error) {
+	var message models.Message
+	err := s.db.QueryRowContext(ctx, "SELECT id, application_id, event_type, payload, created_at FROM messages WHERE id=$1", messageID).
+		Scan(&message.ID, &message.ApplicationID, &message.EventType, &message.Payload, &message.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Message{}, models.ErrMessageNotFound
+		}
+		return models.Message{}, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// outboxChannel is the NOTIFY channel signalled whenever outbox entries become due.
+const outboxChannel = "hookify_outbox"
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func notifyOutbox(ctx context.Context, db execer) error {
+	if _, err := db.ExecContext(ctx, "SELECT pg_notify($1, '')", outboxChannel); err != nil {
+		return fmt.Errorf("failed to notify outbox listeners: %w", err)
+	}
+	return nil
+}
+
+// ListenOutbox subscribes to outbox notifications on a dedicated connection
+// until ctx is done. The returned channel receives a value whenever entries
+// may have become due; notifications arriving while one is pending are
+// coalesced. After a lost connection is re-established a value is sent as
+// well, since notifications may have been missed in between.
+func (s *Storage) ListenOutbox(ctx context.Context) (<-chan struct{}, error) {
+	listener := pq.NewListener(s.dsn, time.Second, time.Minute, nil)
+	if err := listener.Listen(outboxChannel); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", outboxChannel, err)
+	}
+
+	wakeups := make(chan struct{}, 1)
+	go func() {
+		defer func() { _ = listener.Close() }()
+
+		// Pinging detects a silently dropped connection so pq reconnects.
+		ping := time.NewTicker(time.Minute)
+		defer ping.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-listener.Notify:
+				select {
+				case wakeups <- struct{}{}:
+				default:
+				}
+			case <-ping.C:
+				_ = listener.Ping()
+			}
+		}
+	}()
+
+	return wakeups, nil
+}
@@ -1,18 +1,24 @@
 package postgres
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"hookify/internal/models"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Storage struct {
-	db *sql.DB
+	db  *sql.DB
+	dsn string
 }
 
 func New(dsn string) (*Storage, error) {
@@ -32,73 +38,541 @@ func New(dsn string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to ping postgres: %w", err)
 	}
 
-	return &Storage{db: db}, nil
+	return &Storage{db: db, dsn: dsn}, nil
 }
 
 func (s *Storage) Close() error {
 	return s.db.Close()
 }
 
-func (s *Storage) SaveEventWithOutbox(ctx context.Context, webhookID int64, payload string) (int64, error) {
+// Ping reports whether the database is reachable.
+func (s *Storage) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping postgres: %w", err)
+	}
+	return nil
+}
+
+// SaveEventWithOutbox stores an event together with the outbox entry that
+// publishes it. A non-empty idempotencyKey is reserved for the webhook until
+// retention has passed; while it is, saving again with the same key returns
+// the original event with created set to false instead of a new event.
+func (s *Storage) SaveEventWithOutbox(ctx context.Context, webhookID int64, eventType string, payload string, idempotencyKey string, retention time.Duration) (eventID int64, created bool, err error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, false, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	var eventID int64
-	err = tx.QueryRowContext(ctx, "INSERT INTO events(webhook_id, payload, status) VALUES($1, $2, $3) RETURNING id", webhookID, payload, models.EventStatusPending).Scan(&eventID)
+	if idempotencyKey != "" {
+		existingID, reserved, err := reserveIdempotencyKey(ctx, tx, webhookID, idempotencyKey, retention)
+		if err != nil {
+			return 0, false, err
+		}
+		if !reserved {
+			return existingID, false, nil
+		}
+	}
+
+	eventID, err = insertEvent(ctx, tx, webhookID, eventType, payload)
 	if err != nil {
-		return 0, fmt.Errorf("failed to insert event: %w", err)
+		return 0, false, err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO outbox(event_id, webhook_id, event_type, payload, attempts, next_attempt_at, type) VALUES($1, $2, $3, $4, 0, NOW(), $5)", eventID, webhookID, eventType, payload, models.OutboxTypePublish)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to insert outbox entry: %w", err)
+	}
+
+	if idempotencyKey != "" {
+		_, err = tx.ExecContext(ctx, "UPDATE idempotency_keys SET event_id=$3 WHERE webhook_id=$1 AND key=$2", webhookID, idempotencyKey, eventID)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to record idempotency key: %w", err)
+		}
 	}
 
-	_, err = tx.ExecContext(ctx, "INSERT INTO outbox(event_id, webhook_id, payload, attempts, next_attempt_at, type) VALUES($1, $2, $3, 0, NOW(), $4)", eventID, webhookID, payload, models.OutboxTypePublish)
+	// Delivered to listeners once the transaction commits.
+	if err := notifyOutbox(ctx, tx); err != nil {
+		return 0, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return eventID, true, nil
+}
+
+// SaveEventsWithOutbox stores a batch of events and their outbox entries in a
+// single transaction using multi-row statements. The returned results line up
+// with events. Idempotency keys behave as in SaveEventWithOutbox; a key
+// repeated within the batch resolves to the event of its first use. Events of
+// webhooks that do not exist get ErrWebhookNotFound and the rest are stored.
+func (s *Storage) SaveEventsWithOutbox(ctx context.Context, events []models.EventSubmission, retention time.Duration) ([]models.SubmissionResult, error) {
+	results := make([]models.SubmissionResult, len(events))
+	if len(events) == 0 {
+		return results, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to insert outbox entry: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	existing, err := lockWebhooks(ctx, tx, events)
+	if err != nil {
+		return nil, err
+	}
+
+	type idempotencyKey struct {
+		webhookID int64
+		key       string
+	}
+	var (
+		firstUse = make(map[idempotencyKey]int)
+		repeats  = make(map[int]int)
+		pending  []int
+		counts   = make(map[int64]int)
+	)
+	for i, e := range events {
+		if !existing[e.WebhookID] {
+			results[i].Err = models.ErrWebhookNotFound
+			continue
+		}
+		if e.IdempotencyKey != "" {
+			k := idempotencyKey{webhookID: e.WebhookID, key: e.IdempotencyKey}
+			if first, ok := firstUse[k]; ok {
+				repeats[i] = first
+				continue
+			}
+			firstUse[k] = i
+
+			existingID, reserved, err := reserveIdempotencyKey(ctx, tx, e.WebhookID, e.IdempotencyKey, retention)
+			if err != nil {
+				return nil, err
+			}
+			if !reserved {
+				results[i].EventID = existingID
+				continue
+			}
+		}
+		pending = append(pending, i)
+		counts[e.WebhookID]++
+	}
+
+	if len(pending) > 0 {
+		if err := insertEvents(ctx, tx, events, pending, counts, results); err != nil {
+			return nil, err
+		}
+		if err := insertPublishEntries(ctx, tx, pending, results); err != nil {
+			return nil, err
+		}
+		if err := recordIdempotencyKeys(ctx, tx, events, pending, results); err != nil {
+			return nil, err
+		}
+
+		// Delivered to listeners once the transaction commits.
+		if err := notifyOutbox(ctx, tx); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for i, first := range repeats {
+		results[i] = models.SubmissionResult{EventID: results[first].EventID, Err: results[first].Err}
+	}
+
+	return results, nil
+}
+
+// lockWebhooks locks the webhooks the events belong to, in ID order so that
+// concurrent batches cannot deadlock, and returns the ones that exist. Holding
+// the locks keeps the webhooks from being deleted before the batch commits.
+func lockWebhooks(ctx context.Context, tx *sql.Tx, events []models.EventSubmission) (map[int64]bool, error) {
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.WebhookID)
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM webhooks WHERE id = ANY($1) ORDER BY id FOR UPDATE", pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock webhooks: %w", err)
 	}
+	defer rows.Close()
 
-	return eventID, nil
+	existing := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		existing[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhooks: %w", err)
+	}
+
+	return existing, nil
 }
 
-func (s *Storage) GetDueOutboxEntries(ctx context.Context, limit int) ([]models.OutboxEntry, error) {
+// insertEvents allocates counts[webhookID] sequence numbers per webhook and
+// inserts the pending events with them in batch order, filling in their IDs.
+func insertEvents(ctx context.Context, tx *sql.Tx, events []models.EventSubmission, pending []int, counts map[int64]int, results []models.SubmissionResult) error {
+	webhookIDs := make([]int64, 0, len(counts))
+	amounts := make([]int64, 0, len(counts))
+	for id, n := range counts {
+		webhookIDs = append(webhookIDs, id)
+		amounts = append(amounts, int64(n))
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE webhooks SET last_sequence = webhooks.last_sequence + c.n
+		FROM unnest($1::bigint[], $2::bigint[]) AS c(id, n)
+		WHERE webhooks.id = c.id
+		RETURNING webhooks.id, webhooks.last_sequence - c.n`, pq.Array(webhookIDs), pq.Array(amounts))
+	if err != nil {
+		return fmt.Errorf("failed to allocate event sequences: %w", err)
+	}
+	next := make(map[int64]int64, len(counts))
+	for rows.Next() {
+		var id, last int64
+		if err := rows.Scan(&id, &last); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan event sequence: %w", err)
+		}
+		next[id] = last + 1
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate event sequences: %w", err)
+	}
+
+	var (
+		ids        = make([]int64, len(pending))
+		eventTypes = make([]string, len(pending))
+		payloads   = make([]string, len(pending))
+		sequences  = make([]int64, len(pending))
+	)
+	for n, i := range pending {
+		e := events[i]
+		ids[n], eventTypes[n], payloads[n], sequences[n] = e.WebhookID, e.EventType, e.Payload, next[e.WebhookID]
+		next[e.WebhookID]++
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		INSERT INTO events(webhook_id, event_type, payload, status, sequence)
+		SELECT c.webhook_id, c.event_type, c.payload::jsonb, $5::event_status, c.sequence
+		FROM unnest($1::bigint[], $2::text[], $3::text[], $4::bigint[]) WITH ORDINALITY AS c(webhook_id, event_type, payload, sequence, n)
+		ORDER BY c.n
+		RETURNING id, webhook_id, sequence`, pq.Array(ids), pq.Array(eventTypes), pq.Array(payloads), pq.Array(sequences), models.EventStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to insert events: %w", err)
+	}
+	defer rows.Close()
+
+	type eventKey struct{ webhookID, sequence int64 }
+	eventIDs := make(map[eventKey]int64, len(pending))
+	for rows.Next() {
+		var (
+			id  int64
+			key eventKey
+		)
+		if err := rows.Scan(&id, &key.webhookID, &key.sequence); err != nil {
+			return fmt.Errorf("failed to scan event: %w", err)
+		}
+		eventIDs[key] = id
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate events: %w", err)
+	}
+
+	for n, i := range pending {
+		results[i] = models.SubmissionResult{EventID: eventIDs[eventKey{ids[n], sequences[n]}], Created: true}
+	}
+
+	return nil
+}
+
+// insertPublishEntries queues the publish outbox entries of the pending events.
+func insertPublishEntries(ctx context.Context, tx *sql.Tx, pending []int, results []models.SubmissionResult) error {
+	eventIDs := make([]int64, len(pending))
+	for n, i := range pending {
+		eventIDs[n] = results[i].EventID
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO outbox(event_id, webhook_id, event_type, payload, attempts, next_attempt_at, type)
+		SELECT id, webhook_id, event_type, payload, 0, NOW(), $2 FROM events WHERE id = ANY($1) ORDER BY id`,
+		pq.Array(eventIDs), models.OutboxTypePublish)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox entries: %w", err)
+	}
+
+	return nil
+}
+
+// recordIdempotencyKeys points the keys reserved for pending events at them.
+func recordIdempotencyKeys(ctx context.Context, tx *sql.Tx, events []models.EventSubmission, pending []int, results []models.SubmissionResult) error {
+	var (
+		webhookIDs []int64
+		keys       []string
+		eventIDs   []int64
+	)
+	for _, i := range pending {
+		if events[i].IdempotencyKey == "" {
+			continue
+		}
+		webhookIDs = append(webhookIDs, events[i].WebhookID)
+		keys = append(keys, events[i].IdempotencyKey)
+		eventIDs = append(eventIDs, results[i].EventID)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE idempotency_keys SET event_id = c.event_id
+		FROM unnest($1::bigint[], $2::text[], $3::bigint[]) AS c(webhook_id, key, event_id)
+		WHERE idempotency_keys.webhook_id = c.webhook_id AND idempotency_keys.key = c.key`,
+		pq.Array(webhookIDs), pq.Array(keys), pq.Array(eventIDs))
+	if err != nil {
+		return fmt.Errorf("failed to record idempotency keys: %w", err)
+	}
+
+	return nil
+}
+
+// reserveIdempotencyKey claims the key for a new event, taking over an expired
+// reservation. When the key is still held it returns the event saved under it.
+// A concurrent reservation of the same key blocks on the primary key until the
+// other transaction finishes, so the key is never handed out twice.
+func reserveIdempotencyKey(ctx context.Context, tx *sql.Tx, webhookID int64, key string, retention time.Duration) (int64, bool, error) {
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO idempotency_keys(webhook_id, key, expires_at)
+		VALUES($1, $2, NOW() + make_interval(secs => $3::float8))
+		ON CONFLICT (webhook_id, key) DO UPDATE
+		SET event_id = NULL, created_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()`,
+		webhookID, key, retention.Seconds())
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n > 0 {
+		return 0, true, nil
+	}
+
+	var eventID sql.NullInt64
+	err = tx.QueryRowContext(ctx, "SELECT event_id FROM idempotency_keys WHERE webhook_id=$1 AND key=$2", webhookID, key).Scan(&eventID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return eventID.Int64, false, nil
+}
+
+// DeleteExpiredIdempotencyKeys removes reservations whose retention has passed.
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= NOW()")
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return n, nil
+}
+
+// ClaimDueOutboxEntries leases up to limit due entries to workerID for lease.
+// Rows locked by a concurrent claim are skipped, and an entry whose lease has
+// run out, because its worker crashed, can be claimed again. Deliveries to
+// paused webhooks are skipped, as are deliveries to webhooks with an open
+// circuit until it may be probed. For ordered webhooks only the delivery of
+// the earliest pending event can be claimed.
+func (s *Storage) ClaimDueOutboxEntries(ctx context.Context, workerID string, limit int, lease time.Duration) ([]models.OutboxEntry, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, event_id, webhook_id, payload, attempts, next_attempt_at, created_at, type 
-		FROM outbox 
-		WHERE next_attempt_at <= NOW() 
-		ORDER BY next_attempt_at ASC, id ASC 
-		LIMIT $1`, limit)
+		UPDATE outbox
+		SET locked_by = $2, locked_until = NOW() + make_interval(secs => $3::float8)
+		WHERE id IN (
+			SELECT id
+			FROM outbox
+			WHERE next_attempt_at <= NOW()
+				AND (locked_until IS NULL OR locked_until <= NOW())
+				AND NOT (type = $4 AND EXISTS (
+					SELECT 1 FROM webhook_circuits c
+					WHERE c.webhook_id = outbox.webhook_id AND c.state <> $5 AND c.open_until > NOW()))
+				AND NOT (type = $4 AND EXISTS (
+					SELECT 1 FROM webhooks w
+					WHERE w.id = outbox.webhook_id AND w.status = $6))
+				AND NOT (type = $4 AND EXISTS (
+					SELECT 1 FROM webhooks w, events cur, events e
+					WHERE w.id = outbox.webhook_id AND w.ordered
+						AND cur.id = outbox.event_id
+						AND e.webhook_id = outbox.webhook_id AND e.status = $7 AND e.sequence < cur.sequence))
+			ORDER BY next_attempt_at ASC, id ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, event_id, webhook_id, event_type, payload, attempts, next_attempt_at, created_at, type,
+			COALESCE((SELECT sequence FROM events WHERE events.id = outbox.event_id), 0)`,
+		limit, workerID, lease.Seconds(), models.OutboxTypeDelivery, models.CircuitStateClosed, models.WebhookStatusPaused, models.EventStatusPending)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query outbox: %w", err)
+		return nil, fmt.Errorf("failed to claim outbox entries: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
 	var entries []models.OutboxEntry
 	for rows.Next() {
 		var e models.OutboxEntry
-		if err := rows.Scan(&e.ID, &e.EventID, &e.WebhookID, &e.Payload, &e.Attempts, &e.NextAttemptAt, &e.CreatedAt, &e.Type); err != nil {
+		if err := rows.Scan(&e.ID, &e.EventID, &e.WebhookID, &e.EventType, &e.Payload, &e.Attempts, &e.NextAttemptAt, &e.CreatedAt, &e.Type, &e.Sequence); err != nil {
 			return nil, fmt.Errorf("failed to scan outbox entry: %w", err)
 		}
 		entries = append(entries, e)
 	}
-	return entries, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not preserve the order of the subquery.
+	slices.SortFunc(entries, func(a, b models.OutboxEntry) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return entries, nil
 }
 
+// SaveOutboxEntry queues a job for the event. It returns 0 when the event
+// already has a job of that type queued; see SaveOutboxEntries.
 func (s *Storage) SaveOutboxEntry(ctx context.Context, eventID int64, webhookID int64, payload string, attempts int, nextAttemptAt time.Time, jobType models.OutboxType) (int64, error) {
-	var id int64
-	err := s.db.QueryRowContext(ctx, "INSERT INTO outbox(event_id, webhook_id, payload, attempts, next_attempt_at, type) VALUES($1, $2, $3, $4, $5, $6) RETURNING id", eventID, webhookID, payload, attempts, nextAttemptAt, jobType).Scan(&id)
+	ids, err := s.SaveOutboxEntries(ctx, []models.OutboxEntry{{
+		Type:          jobType,
+		EventID:       eventID,
+		WebhookID:     webhookID,
+		Payload:       payload,
+		Attempts:      attempts,
+		NextAttemptAt: nextAttemptAt,
+	}})
 	if err != nil {
-		return 0, fmt.Errorf("failed to insert outbox entry: %w", err)
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
 	}
+	return ids[0], nil
+}
 
-	return id, nil
+// SaveOutboxEntries queues the entries in one transaction and returns the IDs
+// of those queued. Each entry takes its event's type from the stored event, and
+// a delivery entry resets its event to pending. An entry is skipped when its
+// event already has a job of the same type queued, so a job is never queued
+// twice.
+func (s *Storage) SaveOutboxEntries(ctx context.Context, entries []models.OutboxEntry) ([]int64, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	eventIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		eventIDs[i] = entry.EventID
+	}
+
+	// Locking the events first makes a concurrent save for the same events
+	// wait, so the check below sees the entries it queued.
+	if _, err := tx.ExecContext(ctx, "SELECT id FROM events WHERE id = ANY($1) ORDER BY id FOR UPDATE", pq.Array(eventIDs)); err != nil {
+		return nil, fmt.Errorf("failed to lock events: %w", err)
+	}
+
+	type job struct {
+		eventID int64
+		jobType models.OutboxType
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT event_id, type FROM outbox WHERE event_id = ANY($1)", pq.Array(eventIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox entries: %w", err)
+	}
+	queued := make(map[job]bool)
+	for rows.Next() {
+		var j job
+		if err := rows.Scan(&j.eventID, &j.jobType); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan outbox entry: %w", err)
+		}
+		queued[j] = true
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox entries: %w", err)
+	}
+
+	var (
+		ids       []int64
+		delivered []int64
+		due       bool
+		now       = time.Now()
+	)
+	for _, entry := range entries {
+		j := job{eventID: entry.EventID, jobType: entry.Type}
+		if queued[j] {
+			continue
+		}
+		queued[j] = true
+
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO outbox(event_id, webhook_id, event_type, payload, attempts, next_attempt_at, type)
+			VALUES($1, $2, COALESCE((SELECT event_type FROM events WHERE id = $1), ''), $3, $4, $5, $6)
+			RETURNING id`, entry.EventID, entry.WebhookID, entry.Payload, entry.Attempts, entry.NextAttemptAt, entry.Type).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert outbox entry: %w", err)
+		}
+		ids = append(ids, id)
+
+		if entry.Type == models.OutboxTypeDelivery {
+			delivered = append(delivered, entry.EventID)
+		}
+		if !entry.NextAttemptAt.After(now) {
+			due = true
+		}
+	}
+
+	if len(delivered) > 0 {
+		if _, err := tx.ExecContext(ctx, "UPDATE events SET status=$2 WHERE id = ANY($1)", pq.Array(delivered), models.EventStatusPending); err != nil {
+			return nil, fmt.Errorf("failed to reset event status: %w", err)
+		}
+	}
+
+	// Entries scheduled for later are picked up by the polling fallback.
+	if due {
+		if err := notifyOutbox(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return ids, nil
 }
 
+// UpdateOutboxEntry reschedules the entry and releases its lease.
 func (s *Storage) UpdateOutboxEntry(ctx context.Context, id int64, attempts int, nextAttemptAt time.Time) error {
-	_, err := s.db.ExecContext(ctx, "UPDATE outbox SET attempts=$1, next_attempt_at=$2 WHERE id=$3", attempts, nextAttemptAt, id)
+	_, err := s.db.ExecContext(ctx, "UPDATE outbox SET attempts=$1, next_attempt_at=$2, locked_by=NULL, locked_until=NULL WHERE id=$3", attempts, nextAttemptAt, id)
 	if err != nil {
 		return fmt.Errorf("failed to update outbox entry: %w", err)
 	}
@@ -113,10 +587,19 @@ func (s *Storage) DeleteOutboxEntry(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (s *Storage) SaveWebhook(ctx context.Context, url string, secret string) (int64, error) {
+// SaveWebhook stores a webhook, attached to the application when applicationID
+// is positive.
+func (s *Storage) SaveWebhook(ctx context.Context, url string, secret string, apiKeyHash string, applicationID int64) (int64, error) {
 	var id int64
-	err := s.db.QueryRowContext(ctx, "INSERT INTO webhooks(url, secret) VALUES($1, $2) RETURNING id", url, secret).Scan(&id)
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO webhooks(url, secret, api_key_hash, application_id)
+		SELECT $1, $2, $3, $4::bigint
+		WHERE $4::bigint IS NULL OR EXISTS (SELECT 1 FROM applications WHERE id = $4::bigint)
+		RETURNING id`, url, secret, apiKeyHash, nullID(applicationID)).Scan(&id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, models.ErrApplicationNotFound
+		}
 		return 0, fmt.Errorf("failed to insert webhook: %w", err)
 	}
 
@@ -124,8 +607,7 @@ func (s *Storage) SaveWebhook(ctx context.Context, url string, secret string) (i
 }
 
 func (s *Storage) GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
-	var webhook models.Webhook
-	err := s.db.QueryRowContext(ctx, "SELECT id, url, secret FROM webhooks WHERE id=$1", webhookID).Scan(&webhook.ID, &webhook.URL, &webhook.Secret)
+	webhook, err := scanWebhook(s.db.QueryRowContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id=$1", webhookID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Webhook{}, models.ErrWebhookNotFound
@@ -136,21 +618,774 @@ func (s *Storage) GetWebhook(ctx context.Context, webhookID int64) (models.Webho
 	return webhook, nil
 }
 
-func (s *Storage) SaveEvent(ctx context.Context, webhookID int64, payload string) (int64, error) {
+func (s *Storage) ListWebhooks(ctx context.Context, afterID int64, limit int) ([]models.Webhook, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id > $1 ORDER BY id ASC LIMIT $2", afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (s *Storage) UpdateWebhook(ctx context.Context, webhookID int64, update models.WebhookUpdate) (models.Webhook, error) {
+	var url, signatureScheme sql.NullString
+	if update.URL != nil {
+		url = sql.NullString{String: *update.URL, Valid: true}
+	}
+	if update.SignatureScheme != nil {
+		signatureScheme = sql.NullString{String: string(*update.SignatureScheme), Valid: true}
+	}
+	var ordered sql.NullBool
+	if update.Ordered != nil {
+		ordered = sql.NullBool{Bool: *update.Ordered, Valid: true}
+	}
+	var eventTypes any
+	if update.EventTypes != nil {
+		// A nil array would be sent as NULL and leave the column unchanged.
+		eventTypes = pq.Array(append([]string{}, *update.EventTypes...))
+	}
+	var retryPolicy sql.NullString
+	if update.RetryPolicy != nil {
+		b, err := json.Marshal(update.RetryPolicy)
+		if err != nil {
+			return models.Webhook{}, fmt.Errorf("failed to marshal retry policy: %w", err)
+		}
+		retryPolicy = sql.NullString{String: string(b), Valid: true}
+	}
+
+	webhook, err := scanWebhook(s.db.QueryRowContext(ctx, `
+		UPDATE webhooks
+		SET url = COALESCE($2, url),
+			signature_scheme = COALESCE($3, signature_scheme),
+			retry_policy = CASE WHEN $5 THEN NULL ELSE COALESCE($4::jsonb, retry_policy) END,
+			ordered = COALESCE($6, ordered),
+			event_types = COALESCE($7::text[], event_types)
+		WHERE id = $1
+		RETURNING `+webhookColumns, webhookID, url, signatureScheme, retryPolicy, update.ClearRetryPolicy, ordered, eventTypes))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook removes the webhook together with its pending outbox entries
+// and dead letters. Events are removed by the foreign key cascade. The webhook
+// goes first so a dead letter being requeued meanwhile either finds it gone or
+// has its entry removed here.
+func (s *Storage) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM webhooks WHERE id=$1", webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return models.ErrWebhookNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM outbox WHERE webhook_id=$1", webhookID); err != nil {
+		return fmt.Errorf("failed to delete outbox entries: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM dead_letters WHERE webhook_id=$1", webhookID); err != nil {
+		return fmt.Errorf("failed to delete dead letters: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RotateWebhookSecret replaces the webhook secret, keeping the old one as the
+// previous secret until previousExpiresAt.
+func (s *Storage) RotateWebhookSecret(ctx context.Context, webhookID int64, secret string, previousExpiresAt time.Time) (models.Webhook, error) {
+	webhook, err := scanWebhook(s.db.QueryRowContext(ctx, `
+		UPDATE webhooks
+		SET previous_secret = secret, previous_secret_expires_at = $3, secret = $2
+		WHERE id = $1
+		RETURNING `+webhookColumns, webhookID, secret, previousExpiresAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// RotateWebhookAPIKey replaces the publisher API key hash, keeping the old one
+// valid until previousExpiresAt.
+func (s *Storage) RotateWebhookAPIKey(ctx context.Context, webhookID int64, apiKeyHash string, previousExpiresAt time.Time) (models.Webhook, error) {
+	webhook, err := scanWebhook(s.db.QueryRowContext(ctx, `
+		UPDATE webhooks
+		SET previous_api_key_hash = api_key_hash, previous_api_key_expires_at = $3, api_key_hash = $2
+		WHERE id = $1
+		RETURNING `+webhookColumns, webhookID, apiKeyHash, previousExpiresAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to rotate webhook api key: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// PauseWebhook marks the webhook paused so its deliveries are held in the outbox.
+func (s *Storage) PauseWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	webhook, err := scanWebhook(s.db.QueryRowContext(ctx, `
+		UPDATE webhooks SET status=$2, disabled_reason=''
+		WHERE id=$1
+		RETURNING `+webhookColumns, webhookID, models.WebhookStatusPaused))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to pause webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// ResumeWebhook reactivates a paused or disabled webhook and resets its
+// circuit. Deliveries held by a pause are already due and are claimed again in
+// the order they were queued; retries waiting out their backoff keep their
+// schedule. Resuming is also how a webhook disabled by its circuit breaker or
+// by a 410 Gone response is re-enabled.
+func (s *Storage) ResumeWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	webhook, err := scanWebhook(tx.QueryRowContext(ctx, `
+		UPDATE webhooks SET status=$2, disabled_reason=''
+		WHERE id=$1
+		RETURNING `+webhookColumns, webhookID, models.WebhookStatusActive))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, models.ErrWebhookNotFound
+		}
+		return models.Webhook{}, fmt.Errorf("failed to resume webhook: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_circuits WHERE webhook_id=$1", webhookID); err != nil {
+		return models.Webhook{}, fmt.Errorf("failed to reset circuit: %w", err)
+	}
+
+	if err := notifyOutbox(ctx, tx); err != nil {
+		return models.Webhook{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Webhook{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// DisableWebhook marks the webhook disabled, recording why.
+func (s *Storage) DisableWebhook(ctx context.Context, webhookID int64, reason string) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE webhooks SET status=$2, disabled_reason=$3 WHERE id=$1", webhookID, models.WebhookStatusDisabled, reason)
+	if err != nil {
+		return fmt.Errorf("failed to disable webhook: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return models.ErrWebhookNotFound
+	}
+	return nil
+}
+
+const webhookColumns = `id, application_id, url, secret, previous_secret, previous_secret_expires_at,
+	api_key_hash, previous_api_key_hash, previous_api_key_expires_at, signature_scheme, retry_policy, status, disabled_reason, ordered, event_types, created_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row rowScanner) (models.Webhook, error) {
+	var (
+		webhook                 models.Webhook
+		applicationID           sql.NullInt64
+		previousSecret          sql.NullString
+		previousSecretExpiresAt sql.NullTime
+		previousAPIKeyHash      sql.NullString
+		previousAPIKeyExpiresAt sql.NullTime
+		retryPolicy             []byte
+	)
+	err := row.Scan(
+		&webhook.ID, &applicationID, &webhook.URL, &webhook.Secret, &previousSecret, &previousSecretExpiresAt,
+		&webhook.APIKeyHash, &previousAPIKeyHash, &previousAPIKeyExpiresAt, &webhook.SignatureScheme, &retryPolicy,
+		&webhook.Status, &webhook.DisabledReason, &webhook.Ordered, (*pq.StringArray)(&webhook.EventTypes), &webhook.CreatedAt,
+	)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	webhook.ApplicationID = applicationID.Int64
+	webhook.PreviousSecret = previousSecret.String
+	if previousSecretExpiresAt.Valid {
+		webhook.PreviousSecretExpiresAt = &previousSecretExpiresAt.Time
+	}
+	webhook.PreviousAPIKeyHash = previousAPIKeyHash.String
+	if previousAPIKeyExpiresAt.Valid {
+		webhook.PreviousAPIKeyExpiresAt = &previousAPIKeyExpiresAt.Time
+	}
+	if retryPolicy != nil {
+		webhook.RetryPolicy = &models.RetryPolicy{}
+		if err := json.Unmarshal(retryPolicy, webhook.RetryPolicy); err != nil {
+			return models.Webhook{}, fmt.Errorf("failed to unmarshal retry policy: %w", err)
+		}
+	}
+	return webhook, nil
+}
+
+func (s *Storage) SaveEvent(ctx context.Context, webhookID int64, eventType string, payload string) (int64, error) {
+	return insertEvent(ctx, s.db, webhookID, eventType, payload)
+}
+
+// insertEvent stores a pending event under the webhook's next sequence number.
+// Taking the number locks the webhook row, so the sequence follows commit order.
+func insertEvent(ctx context.Context, db queryRower, webhookID int64, eventType string, payload string) (int64, error) {
 	var id int64
-	err := s.db.QueryRowContext(ctx, "INSERT INTO events(webhook_id, payload) VALUES($1, $2) RETURNING id", webhookID, payload).Scan(&id)
+	err := db.QueryRowContext(ctx, `
+		WITH seq AS (
+			UPDATE webhooks SET last_sequence = last_sequence + 1 WHERE id = $1 RETURNING last_sequence)
+		INSERT INTO events(webhook_id, event_type, payload, status, sequence)
+		SELECT $1, $2, $3, $4, last_sequence FROM seq
+		RETURNING id`, webhookID, eventType, payload, models.EventStatusPending).Scan(&id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, models.ErrWebhookNotFound
+		}
 		return 0, fmt.Errorf("failed to insert event: %w", err)
 	}
 
 	return id, nil
 }
 
+// UpdateEventStatus sets the event's status. When an event of an ordered
+// webhook stops being pending, the next one in sequence may have become
+// deliverable, so outbox workers are woken up.
 func (s *Storage) UpdateEventStatus(ctx context.Context, eventID int64, status models.EventStatus) error {
-	_, err := s.db.ExecContext(ctx, "UPDATE events SET status=$1 WHERE id=$2", status, eventID)
+	var ordered sql.NullBool
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE events SET status=$1 WHERE id=$2
+		RETURNING (SELECT ordered FROM webhooks WHERE webhooks.id = events.webhook_id)`, status, eventID).Scan(&ordered)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to update event status: %w", err)
+	}
+
+	if ordered.Bool && status != models.EventStatusPending {
+		if err := notifyOutbox(ctx, s.db); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsHeadOfLine reports whether no earlier event of the webhook is still pending.
+func (s *Storage) IsHeadOfLine(ctx context.Context, webhookID int64, eventID int64) (bool, error) {
+	var head bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT NOT EXISTS (
+			SELECT 1 FROM events e, events cur
+			WHERE cur.id = $2 AND e.webhook_id = $1 AND e.status = $3 AND e.sequence < cur.sequence)`,
+		webhookID, eventID, models.EventStatusPending).Scan(&head)
+	if err != nil {
+		return false, fmt.Errorf("failed to check head of line: %w", err)
+	}
+	return head, nil
+}
+
+func (s *Storage) GetEvent(ctx context.Context, eventID int64) (models.RawEvent, error) {
+	event, err := scanEvent(s.db.QueryRowContext(ctx, "SELECT "+eventColumns+" FROM events WHERE id=$1", eventID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RawEvent{}, models.ErrEventNotFound
+		}
+		return models.RawEvent{}, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return event, nil
+}
+
+// ListEvents returns up to limit events matching the filter, newest first.
+// When beforeID is positive only events with smaller IDs are returned.
+func (s *Storage) ListEvents(ctx context.Context, filter models.EventFilter, beforeID int64, limit int) ([]models.RawEvent, error) {
+	return s.listEvents(ctx, filter, beforeID, false, limit)
+}
+
+// ListEventsAfter returns up to limit events matching the filter with IDs
+// greater than afterID, oldest first.
+func (s *Storage) ListEventsAfter(ctx context.Context, filter models.EventFilter, afterID int64, limit int) ([]models.RawEvent, error) {
+	return s.listEvents(ctx, filter, afterID, true, limit)
+}
+
+// listEvents lists events newest first with IDs below a positive cursor or,
+// when ascending, oldest first with IDs above it.
+func (s *Storage) listEvents(ctx context.Context, filter models.EventFilter, cursor int64, ascending bool, limit int) ([]models.RawEvent, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	addCond := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if filter.WebhookID > 0 {
+		addCond("webhook_id = ?", filter.WebhookID)
+	}
+	if filter.MessageID > 0 {
+		addCond("message_id = ?", filter.MessageID)
+	}
+	if filter.Status != "" {
+		addCond("status = ?", filter.Status)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		addCond("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		addCond("created_at < ?", filter.CreatedBefore)
+	}
+	switch {
+	case cursor > 0 && ascending:
+		addCond("id > ?", cursor)
+	case cursor > 0:
+		addCond("id < ?", cursor)
+	}
+
+	query := "SELECT " + eventColumns + " FROM events"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+	args = append(args, limit)
+	query += " ORDER BY id " + order + " LIMIT $" + strconv.Itoa(len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []models.RawEvent
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+const eventColumns = "id, webhook_id, message_id, event_type, sequence, payload, status, created_at"
+
+func scanEvent(row rowScanner) (models.RawEvent, error) {
+	var (
+		event     models.RawEvent
+		messageID sql.NullInt64
+	)
+	err := row.Scan(&event.ID, &event.WebhookID, &messageID, &event.EventType, &event.Sequence, &event.Payload, &event.Status, &event.CreatedAt)
+	event.MessageID = messageID.Int64
+	return event, err
+}
+
+// SaveDeliveryAttempt stores the attempt, numbering it after the attempts
+// already recorded for the same event.
+func (s *Storage) SaveDeliveryAttempt(ctx context.Context, attempt models.DeliveryAttempt) error {
+	var statusCode sql.NullInt64
+	if attempt.StatusCode != 0 {
+		statusCode = sql.NullInt64{Int64: int64(attempt.StatusCode), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO delivery_attempts(event_id, webhook_id, attempt, requested_at, status_code, response_body, latency_ms, error)
+		SELECT $1, $2, COALESCE(MAX(attempt), 0) + 1, $3, $4, $5, $6, $7
+		FROM delivery_attempts WHERE event_id = $1`,
+		attempt.EventID, attempt.WebhookID, attempt.RequestedAt, statusCode, attempt.ResponseBody, attempt.Latency.Milliseconds(), attempt.Error)
+	if err != nil {
+		return fmt.Errorf("failed to insert delivery attempt: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveryAttempts returns up to limit attempts for the event with IDs
+// greater than afterID, oldest first.
+func (s *Storage) ListDeliveryAttempts(ctx context.Context, eventID int64, afterID int64, limit int) ([]models.DeliveryAttempt, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, event_id, webhook_id, attempt, requested_at, status_code, response_body, latency_ms, error
+		FROM delivery_attempts
+		WHERE event_id = $1 AND id > $2
+		ORDER BY id ASC
+		LIMIT $3`, eventID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delivery attempts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var attempts []models.DeliveryAttempt
+	for rows.Next() {
+		var (
+			a          models.DeliveryAttempt
+			statusCode sql.NullInt64
+			latencyMS  int64
+		)
+		if err := rows.Scan(&a.ID, &a.EventID, &a.WebhookID, &a.Attempt, &a.RequestedAt, &statusCode, &a.ResponseBody, &latencyMS, &a.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery attempt: %w", err)
+		}
+		a.StatusCode = int(statusCode.Int64)
+		a.Latency = time.Duration(latencyMS) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+	return attempts, rows.Err()
+}
+
+// GetCircuit returns the webhook's circuit breaker, or a closed circuit when
+// none has been stored yet.
+func (s *Storage) GetCircuit(ctx context.Context, webhookID int64) (models.Circuit, error) {
+	circuit, err := scanCircuit(s.db.QueryRowContext(ctx, "SELECT "+circuitColumns+" FROM webhook_circuits WHERE webhook_id=$1", webhookID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Circuit{WebhookID: webhookID, State: models.CircuitStateClosed}, nil
+		}
+		return models.Circuit{}, fmt.Errorf("failed to get circuit: %w", err)
+	}
+
+	return circuit, nil
+}
+
+// TryHalfOpenCircuit moves an open circuit whose open_until has passed to
+// half-open, leasing the probe for probeTimeout. It reports whether this
+// caller won the probe.
+func (s *Storage) TryHalfOpenCircuit(ctx context.Context, webhookID int64, probeTimeout time.Duration) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_circuits
+		SET state=$2, open_until=NOW() + make_interval(secs => $3::float8), updated_at=NOW()
+		WHERE webhook_id=$1 AND state IN ($4, $2) AND open_until <= NOW()`,
+		webhookID, models.CircuitStateHalfOpen, probeTimeout.Seconds(), models.CircuitStateOpen)
+	if err != nil {
+		return false, fmt.Errorf("failed to half-open circuit: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return n == 1, nil
+}
+
+// RecordCircuitSuccess closes the circuit and resets its failure streak.
+func (s *Storage) RecordCircuitSuccess(ctx context.Context, webhookID int64) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_circuits
+		SET state=$2, consecutive_failures=0, open_until=NULL, updated_at=NOW()
+		WHERE webhook_id=$1 AND (state <> $2 OR consecutive_failures > 0)`,
+		webhookID, models.CircuitStateClosed)
+	if err != nil {
+		return fmt.Errorf("failed to record circuit success: %w", err)
+	}
+	return nil
+}
+
+// RecordCircuitFailure extends the circuit's failure streak. The circuit is
+// (re)opened for cooldown once the streak reaches threshold or when a
+// half-open probe fails.
+func (s *Storage) RecordCircuitFailure(ctx context.Context, webhookID int64, threshold int, cooldown time.Duration) (models.Circuit, error) {
+	circuit, err := scanCircuit(s.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_circuits AS c (webhook_id, state, consecutive_failures, open_until, updated_at)
+		VALUES ($1,
+			CASE WHEN $2 <= 1 THEN $3 ELSE $4 END,
+			1,
+			CASE WHEN $2 <= 1 THEN NOW() + make_interval(secs => $5::float8) END,
+			NOW())
+		ON CONFLICT (webhook_id) DO UPDATE SET
+			state = CASE WHEN c.state = $6 OR c.consecutive_failures + 1 >= $2 THEN $3 ELSE c.state END,
+			consecutive_failures = c.consecutive_failures + 1,
+			open_until = CASE WHEN c.state = $6 OR c.consecutive_failures + 1 >= $2
+				THEN NOW() + make_interval(secs => $5::float8) ELSE c.open_until END,
+			updated_at = NOW()
+		RETURNING `+circuitColumns,
+		webhookID, threshold, models.CircuitStateOpen, models.CircuitStateClosed, cooldown.Seconds(), models.CircuitStateHalfOpen))
+	if err != nil {
+		return models.Circuit{}, fmt.Errorf("failed to record circuit failure: %w", err)
+	}
+
+	return circuit, nil
+}
+
+const circuitColumns = "webhook_id, state, consecutive_failures, open_until, updated_at"
+
+func scanCircuit(row rowScanner) (models.Circuit, error) {
+	var (
+		circuit   models.Circuit
+		openUntil sql.NullTime
+	)
+	if err := row.Scan(&circuit.WebhookID, &circuit.State, &circuit.ConsecutiveFailures, &openUntil, &circuit.UpdatedAt); err != nil {
+		return models.Circuit{}, err
+	}
+	if openUntil.Valid {
+		circuit.OpenUntil = &openUntil.Time
+	}
+	return circuit, nil
+}
+
+// SaveDeadLetter stores a dead letter.
+func (s *Storage) SaveDeadLetter(ctx context.Context, deadLetter models.DeadLetter) (int64, error) {
+	id, err := insertDeadLetter(ctx, s.db, deadLetter)
 	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// DeadLetterOutboxEntry moves an outbox entry to the dead letters and marks
+// its event failed in one transaction.
+func (s *Storage) DeadLetterOutboxEntry(ctx context.Context, entryID int64, deadLetter models.DeadLetter) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM outbox WHERE id=$1", entryID); err != nil {
+		return fmt.Errorf("failed to delete outbox entry: %w", err)
+	}
+
+	if _, err := insertDeadLetter(ctx, tx, deadLetter); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE events SET status=$1 WHERE id=$2", models.EventStatusFailed, deadLetter.EventID); err != nil {
 		return fmt.Errorf("failed to update event status: %w", err)
 	}
 
+	// Giving up on the head of an ordered webhook releases the next event.
+	if err := notifyOutbox(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func insertDeadLetter(ctx context.Context, db queryRower, d models.DeadLetter) (int64, error) {
+	var id int64
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO dead_letters(source, event_id, webhook_id, type, payload, reason, last_error, attempts)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		d.Source, nullID(d.EventID), nullID(d.WebhookID), d.Type, d.Payload, d.Reason, d.LastError, d.Attempts).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert dead letter: %w", err)
+	}
+	return id, nil
+}
+
+func nullID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id > 0}
+}
+
+func (s *Storage) GetDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error) {
+	deadLetter, err := scanDeadLetter(s.db.QueryRowContext(ctx, "SELECT "+deadLetterColumns+" FROM dead_letters WHERE id=$1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DeadLetter{}, models.ErrDeadLetterNotFound
+		}
+		return models.DeadLetter{}, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+
+	return deadLetter, nil
+}
+
+// ListDeadLetters returns up to limit dead letters matching the filter,
+// newest first, starting below beforeID when it is positive.
+func (s *Storage) ListDeadLetters(ctx context.Context, filter models.DeadLetterFilter, beforeID int64, limit int) ([]models.DeadLetter, error) {
+	conds, args := deadLetterConds(filter)
+	if beforeID > 0 {
+		args = append(args, beforeID)
+		conds = append(conds, "id < $"+strconv.Itoa(len(args)))
+	}
+
+	query := "SELECT " + deadLetterColumns + " FROM dead_letters"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, limit)
+	query += " ORDER BY id DESC LIMIT $" + strconv.Itoa(len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deadLetters []models.DeadLetter
+	for rows.Next() {
+		deadLetter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	return deadLetters, rows.Err()
+}
+
+// RequeueDeadLetter removes the dead letter and queues its payload again as a
+// fresh outbox entry of the original type, resetting the event to pending.
+// The dead letter is kept when its webhook or event has been deleted since.
+func (s *Storage) RequeueDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	deadLetter, err := scanDeadLetter(tx.QueryRowContext(ctx, "DELETE FROM dead_letters WHERE id=$1 RETURNING "+deadLetterColumns, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DeadLetter{}, models.ErrDeadLetterNotFound
+		}
+		return models.DeadLetter{}, fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	// Locking the webhook and event keeps them from being deleted until the
+	// entry is queued.
+	var exists bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM webhooks WHERE id=$1 FOR SHARE)", deadLetter.WebhookID).Scan(&exists); err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to check webhook: %w", err)
+	}
+	if !exists {
+		return models.DeadLetter{}, models.ErrWebhookNotFound
+	}
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM events WHERE id=$1 FOR SHARE)", deadLetter.EventID).Scan(&exists); err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to check event: %w", err)
+	}
+	if !exists {
+		return models.DeadLetter{}, models.ErrEventNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox(event_id, webhook_id, event_type, payload, attempts, next_attempt_at, type)
+		VALUES($1, $2, COALESCE((SELECT event_type FROM events WHERE id = $1), ''), $3, 0, NOW(), $4)`,
+		deadLetter.EventID, deadLetter.WebhookID, deadLetter.Payload, deadLetter.Type)
+	if err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to insert outbox entry: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE events SET status=$1 WHERE id=$2", models.EventStatusPending, deadLetter.EventID); err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to update event status: %w", err)
+	}
+
+	if err := notifyOutbox(ctx, tx); err != nil {
+		return models.DeadLetter{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.DeadLetter{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deadLetter, nil
+}
+
+// PurgeDeadLetters deletes the dead letters matching the filter and reports how many were removed.
+func (s *Storage) PurgeDeadLetters(ctx context.Context, filter models.DeadLetterFilter) (int64, error) {
+	conds, args := deadLetterConds(filter)
+	query := "DELETE FROM dead_letters"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge dead letters: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return n, nil
+}
+
+func deadLetterConds(filter models.DeadLetterFilter) ([]string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	addCond := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if filter.WebhookID > 0 {
+		addCond("webhook_id = ?", filter.WebhookID)
+	}
+	if filter.Source != "" {
+		addCond("source = ?", filter.Source)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		addCond("created_at < ?", filter.CreatedBefore)
+	}
+	return conds, args
+}
+
+const deadLetterColumns = "id, source, event_id, webhook_id, type, payload, reason, last_error, attempts, created_at"
+
+func scanDeadLetter(row rowScanner) (models.DeadLetter, error) {
+	var (
+		d         models.DeadLetter
+		eventID   sql.NullInt64
+		webhookID sql.NullInt64
+	)
+	err := row.Scan(&d.ID, &d.Source, &eventID, &webhookID, &d.Type, &d.Payload, &d.Reason, &d.LastError, &d.Attempts, &d.CreatedAt)
+	if err != nil {
+		return models.DeadLetter{}, err
+	}
+	d.EventID = eventID.Int64
+	d.WebhookID = webhookID.Int64
+	return d, nil
+}
@@ -0,0 +1,361 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "hookify/gen/hookify"
+	"hookify/internal/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxGatewayBodySize matches the default gRPC limit on received messages.
+const maxGatewayBodySize = 4 << 20
+
+// maxGatewayStreamBodySize bounds the body of a streaming route, which holds
+// many requests.
+const maxGatewayStreamBodySize = 16 * maxGatewayBodySize
+
+var gatewayMarshal = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// gatewayCall decodes the request of an RPC from r and invokes the RPC
+// through the interceptors of the gateway.
+type gatewayCall func(g *gateway, r *http.Request, fullMethod string, fromBody bool) (proto.Message, error)
+
+// gateway runs RPC handlers through the same interceptors as the gRPC
+// server.
+type gateway struct {
+	unary  grpc.UnaryServerInterceptor
+	stream grpc.StreamServerInterceptor
+	log    *slog.Logger
+}
+
+// gatewayRoute exposes one Hookify RPC as a REST endpoint. Requests of POST
+// and PATCH routes are read from the JSON body and those of GET and DELETE
+// routes from the query string; path wildcards set the request field of the
+// same name.
+type gatewayRoute struct {
+	method string
+	path   string
+	rpc    protoreflect.Name
+	call   gatewayCall
+}
+
+func gatewayRoutes(s *serverAPI) []gatewayRoute {
+	return []gatewayRoute{
+		{http.MethodPost, "/v1/webhooks", "CreateWebhook", unary(s.CreateWebhook)},
+		{http.MethodGet, "/v1/webhooks", "ListWebhooks", unary(s.ListWebhooks)},
+		{http.MethodGet, "/v1/webhooks/{webhook_id}", "GetWebhook", unary(s.GetWebhook)},
+		{http.MethodPatch, "/v1/webhooks/{webhook_id}", "UpdateWebhook", unary(s.UpdateWebhook)},
+		{http.MethodDelete, "/v1/webhooks/{webhook_id}", "DeleteWebhook", unary(s.DeleteWebhook)},
+		{http.MethodPost, "/v1/webhooks/{webhook_id}/pause", "PauseWebhook", unary(s.PauseWebhook)},
+		{http.MethodPost, "/v1/webhooks/{webhook_id}/resume", "ResumeWebhook", unary(s.ResumeWebhook)},
+		{http.MethodPost, "/v1/webhooks/{webhook_id}/rotate-secret", "RotateWebhookSecret", unary(s.RotateWebhookSecret)},
+		{http.MethodPost, "/v1/webhooks/{webhook_id}/rotate-api-key", "RotateWebhookApiKey", unary(s.RotateWebhookApiKey)},
+		{http.MethodPost, "/v1/webhooks/{webhook_id}/events", "SubmitEvent", unary(s.SubmitEvent)},
+		{http.MethodPost, "/v1/webhooks/{webhook_id}/replay", "ReplayEvents", unary(s.ReplayEvents)},
+		{http.MethodPost, "/v1/events/batch", "SubmitEvents", unary(s.SubmitEvents)},
+		{http.MethodPost, "/v1/events/stream", "SubmitEventStream", submitEventStream(s)},
+		{http.MethodGet, "/v1/events", "ListEvents", unary(s.ListEvents)},
+		{http.MethodGet, "/v1/events/{event_id}", "GetEvent", unary(s.GetEvent)},
+		{http.MethodGet, "/v1/events/{event_id}/attempts", "ListDeliveryAttempts", unary(s.ListDeliveryAttempts)},
+		{http.MethodPost, "/v1/events/{event_id}/redeliver", "RedeliverEvent", unary(s.RedeliverEvent)},
+		{http.MethodGet, "/v1/dead-letters", "ListDeadLetters", unary(s.ListDeadLetters)},
+		{http.MethodDelete, "/v1/dead-letters", "PurgeDeadLetters", unary(s.PurgeDeadLetters)},
+		{http.MethodPost, "/v1/dead-letters/{dead_letter_id}/requeue", "RequeueDeadLetter", unary(s.RequeueDeadLetter)},
+		{http.MethodPost, "/v1/applications", "CreateApplication", unary(s.CreateApplication)},
+		{http.MethodGet, "/v1/applications/{application_id}", "GetApplication", unary(s.GetApplication)},
+		{http.MethodDelete, "/v1/applications/{application_id}", "DeleteApplication", unary(s.DeleteApplication)},
+		{http.MethodPost, "/v1/applications/{application_id}/messages", "PublishMessage", unary(s.PublishMessage)},
+		{http.MethodGet, "/v1/messages/{message_id}", "GetMessage", unary(s.GetMessage)},
+	}
+}
+
+// NewGateway returns an HTTP handler that serves every Hookify RPC as
+// REST/JSON through the same handlers and interceptors Register and the gRPC
+// server use, together with the OpenAPI document of the routes at
+// /openapi.json. Errors are written as google.rpc.Status JSON with the HTTP
+// status matching the code.
+func NewGateway(api WebhookAPI, egressPolicy models.EgressPolicy, log *slog.Logger, requestTimeout time.Duration) (http.Handler, error) {
+	routes := gatewayRoutes(&serverAPI{webhookAPI: api, egressPolicy: egressPolicy, log: log})
+
+	doc, err := json.Marshal(openAPIDocument(routes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal openapi document: %w", err)
+	}
+
+	g := &gateway{
+		unary:  chainUnary(UnaryInterceptors(log, requestTimeout)),
+		stream: chainStream(StreamInterceptors(log, requestTimeout)),
+		log:    log,
+	}
+	service := hookifyService().FullName()
+
+	mux := http.NewServeMux()
+	for _, rt := range routes {
+		mux.Handle(rt.method+" "+rt.path, g.handler(rt, fmt.Sprintf("/%s/%s", service, rt.rpc)))
+	}
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(doc)
+	})
+
+	return mux, nil
+}
+
+func (g *gateway) handler(rt gatewayRoute, fullMethod string) http.HandlerFunc {
+	fromBody := rt.method == http.MethodPost || rt.method == http.MethodPatch
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The request ID interceptor reads the ID from the incoming metadata
+		// but can only return it in gRPC headers, so it is settled here.
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		r = r.WithContext(metadata.NewIncomingContext(r.Context(), metadata.Pairs(requestIDHeader, id)))
+
+		resp, err := rt.call(g, r, fullMethod, fromBody)
+		if err != nil {
+			g.write(w, httpStatusFromCode(status.Code(err)), status.Convert(err).Proto())
+			return
+		}
+		g.write(w, http.StatusOK, resp)
+	}
+}
+
+func (g *gateway) write(w http.ResponseWriter, code int, msg proto.Message) {
+	body, err := gatewayMarshal.Marshal(msg)
+	if err != nil {
+		g.log.Error("failed to marshal gateway response", "error", err)
+		code = http.StatusInternalServerError
+		body = []byte(`{"code":13,"message":"internal error","details":[]}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// unary adapts a unary RPC handler to the gateway.
+func unary[Req, Resp proto.Message](handler func(context.Context, Req) (Resp, error)) gatewayCall {
+	return func(g *gateway, r *http.Request, fullMethod string, fromBody bool) (proto.Message, error) {
+		var zero Req
+		req := zero.ProtoReflect().New().Interface().(Req)
+		if err := decodeGatewayRequest(r, req, fromBody); err != nil {
+			return nil, err
+		}
+
+		info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+		resp, err := g.unary(r.Context(), req, info, func(ctx context.Context, req any) (any, error) {
+			return handler(ctx, req.(Req))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(proto.Message), nil
+	}
+}
+
+// submitEventStream adapts SubmitEventStream to the gateway. The body holds
+// a sequence of SubmitEventRequest JSON objects, typically one per line.
+func submitEventStream(s *serverAPI) gatewayCall {
+	return func(g *gateway, r *http.Request, fullMethod string, _ bool) (proto.Message, error) {
+		var handler grpc.StreamHandler
+		for _, desc := range pb.Hookify_ServiceDesc.Streams {
+			if desc.StreamName == "SubmitEventStream" {
+				handler = desc.Handler
+			}
+		}
+
+		body := http.MaxBytesReader(nil, r.Body, maxGatewayStreamBodySize)
+		stream := &gatewayStream{ctx: r.Context(), dec: json.NewDecoder(body)}
+		info := &grpc.StreamServerInfo{FullMethod: fullMethod, IsClientStream: true}
+		if err := g.stream(s, stream, info, handler); err != nil {
+			return nil, err
+		}
+		return stream.resp, nil
+	}
+}
+
+// decodeGatewayRequest fills req from the body or the query string of r and
+// then from its path wildcards. Values are parsed as the proto JSON mapping
+// parses them, so int64 IDs, enum names and RFC 3339 timestamps all work.
+func decodeGatewayRequest(r *http.Request, req proto.Message, fromBody bool) error {
+	if fromBody {
+		body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxGatewayBodySize))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return status.Errorf(codes.ResourceExhausted, "request body exceeds %d bytes", maxErr.Limit)
+			}
+			return status.Error(codes.InvalidArgument, "failed to read request body")
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := protojson.Unmarshal(body, req); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+			}
+		}
+	}
+
+	fields := req.ProtoReflect().Descriptor().Fields()
+	params := make(map[string]any)
+	if !fromBody {
+		for name, values := range r.URL.Query() {
+			fd := fields.ByName(protoreflect.Name(name))
+			if fd == nil {
+				fd = fields.ByJSONName(name)
+			}
+			if fd == nil {
+				return status.Errorf(codes.InvalidArgument, "unknown query parameter %q", name)
+			}
+			v, err := gatewayParam(fd, values)
+			if err != nil {
+				return err
+			}
+			params[string(fd.Name())] = v
+		}
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if v := r.PathValue(string(fd.Name())); v != "" {
+			params[string(fd.Name())] = v
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request parameters: %v", err)
+	}
+	overlay := req.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(raw, overlay); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request parameters: %v", err)
+	}
+	proto.Merge(req, overlay)
+
+	return nil
+}
+
+// gatewayParam converts the query values of fd to their JSON form. Numbers,
+// timestamps and durations stay strings, which the proto JSON mapping accepts.
+func gatewayParam(fd protoreflect.FieldDescriptor, values []string) (any, error) {
+	if fd.IsList() {
+		list := make([]any, 0, len(values))
+		for _, v := range values {
+			item, err := gatewayScalar(fd, v)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	}
+	if len(values) != 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "query parameter %q must be given once", fd.Name())
+	}
+	return gatewayScalar(fd, values[0])
+}
+
+func gatewayScalar(fd protoreflect.FieldDescriptor, v string) (any, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s", fd.Name())
+		}
+		return b, nil
+	case protoreflect.EnumKind:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n, nil
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// httpStatusFromCode maps the gRPC codes returned by serverAPI to HTTP
+// statuses the same way common gRPC gateways do.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// gatewayStream feeds the JSON values of a request body to a client
+// streaming handler and keeps its response.
+type gatewayStream struct {
+	ctx  context.Context
+	dec  *json.Decoder
+	resp proto.Message
+}
+
+func (s *gatewayStream) RecvMsg(m any) error {
+	var raw json.RawMessage
+	if err := s.dec.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return status.Errorf(codes.ResourceExhausted, "request body exceeds %d bytes", maxErr.Limit)
+		}
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+
+	if err := protojson.Unmarshal(raw, m.(proto.Message)); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func (s *gatewayStream) SendMsg(m any) error {
+	s.resp = m.(proto.Message)
+	return nil
+}
+
+func (s *gatewayStream) Context() context.Context     { return s.ctx }
+func (s *gatewayStream) SetHeader(metadata.MD) error  { return nil }
+func (s *gatewayStream) SendHeader(metadata.MD) error { return nil }
+func (s *gatewayStream) SetTrailer(metadata.MD)       {}
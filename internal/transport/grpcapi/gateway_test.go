@@ -0,0 +1,210 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"hookify/internal/models"
+
+	"google.golang.org/grpc/codes"
+)
+
+func newTestGateway(t *testing.T, api *apiMock) http.Handler {
+	t.Helper()
+	gateway, err := NewGateway(api, models.EgressPolicy{}, slog.New(slog.NewTextHandler(io.Discard, nil)), time.Minute)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	return gateway
+}
+
+func serveGateway(gateway http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	gateway.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestGatewayRoutes_CoverEveryRPC(t *testing.T) {
+	routes := make(map[string]int)
+	for _, rt := range gatewayRoutes(&serverAPI{}) {
+		routes[string(rt.rpc)]++
+	}
+
+	methods := hookifyService().Methods()
+	for i := 0; i < methods.Len(); i++ {
+		name := string(methods.Get(i).Name())
+		if routes[name] != 1 {
+			t.Fatalf("expected one route for %s, got %d", name, routes[name])
+		}
+		delete(routes, name)
+	}
+	if len(routes) != 0 {
+		t.Fatalf("routes for unknown RPCs: %v", routes)
+	}
+}
+
+func TestGateway_SubmitEventFromBodyAndPath(t *testing.T) {
+	api := &apiMock{submitID: 11}
+	gateway := newTestGateway(t, api)
+
+	rec := serveGateway(gateway, http.MethodPost, "/v1/webhooks/7/events",
+		`{"payload":"{\"a\":1}","api_key":"key","eventType":"order.created"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if api.submitHookID != 7 || api.submitAPIKey != "key" || api.submitEventType != "order.created" {
+		t.Fatalf("unexpected submission: webhook=%d key=%q type=%q", api.submitHookID, api.submitAPIKey, api.submitEventType)
+	}
+
+	var resp map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("expected JSON response, got %v", err)
+	}
+	if resp["event_id"] != "11" || resp["created"] != true {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+}
+
+func TestGateway_EchoesRequestID(t *testing.T) {
+	gateway := newTestGateway(t, &apiMock{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/webhooks/1", nil)
+	req.Header.Set("X-Request-Id", "req-1")
+	rec := httptest.NewRecorder()
+	gateway.ServeHTTP(rec, req)
+	if got := rec.Header().Get("X-Request-Id"); got != "req-1" {
+		t.Fatalf("expected request ID req-1, got %q", got)
+	}
+
+	rec = serveGateway(gateway, http.MethodGet, "/v1/webhooks/1", "")
+	if rec.Header().Get("X-Request-Id") == "" {
+		t.Fatalf("expected a generated request ID")
+	}
+}
+
+func TestGateway_ListEventsFromQuery(t *testing.T) {
+	api := &apiMock{listEvents: []models.RawEvent{{ID: 9}}}
+	gateway := newTestGateway(t, api)
+
+	rec := serveGateway(gateway, http.MethodGet,
+		"/v1/events?webhook_id=3&status=EVENT_STATUS_FAILED&created_after=2024-01-01T00:00:00Z&page_size=5", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	want := models.EventFilter{WebhookID: 3, Status: models.EventStatusFailed, CreatedAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if api.listFilter != want {
+		t.Fatalf("unexpected filter: %#v", api.listFilter)
+	}
+}
+
+func TestGateway_MapsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		code   codes.Code
+	}{
+		{"not found", http.MethodGet, "/v1/webhooks/1", "", http.StatusNotFound, codes.NotFound},
+		{"invalid body", http.MethodPost, "/v1/webhooks/1/events", `{"payload":`, http.StatusBadRequest, codes.InvalidArgument},
+		{"invalid path", http.MethodGet, "/v1/webhooks/abc", "", http.StatusBadRequest, codes.InvalidArgument},
+		{"unknown query parameter", http.MethodGet, "/v1/webhooks?limit=1", "", http.StatusBadRequest, codes.InvalidArgument},
+		{"validation", http.MethodPost, "/v1/events/batch", `{}`, http.StatusBadRequest, codes.InvalidArgument},
+		{"unfiltered purge", http.MethodDelete, "/v1/dead-letters", "", http.StatusBadRequest, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := newTestGateway(t, &apiMock{getErr: models.ErrWebhookNotFound})
+
+			rec := serveGateway(gateway, tt.method, tt.target, tt.body)
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body)
+			}
+			var body struct {
+				Code    codes.Code `json:"code"`
+				Message string     `json:"message"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("expected JSON status, got %v", err)
+			}
+			if body.Code != tt.code || body.Message == "" {
+				t.Fatalf("expected code %v with a message, got %+v", tt.code, body)
+			}
+		})
+	}
+}
+
+func TestGateway_SubmitEventStream(t *testing.T) {
+	api := &apiMock{}
+	gateway := newTestGateway(t, api)
+
+	body := `{"webhook_id":"1","payload":"{}","api_key":"key"}
+{"webhook_id":"404","payload":"{}","api_key":"key"}
+`
+	rec := serveGateway(gateway, http.MethodPost, "/v1/events/stream", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var resp struct {
+		Results []struct {
+			EventID   string     `json:"event_id"`
+			ErrorCode codes.Code `json:"error_code"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("expected JSON response, got %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].EventID != "1" || resp.Results[1].ErrorCode != codes.NotFound {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+}
+
+func TestGateway_SubmitEventStreamBodyIsBounded(t *testing.T) {
+	gateway := newTestGateway(t, &apiMock{})
+
+	// Few events, padded, so the body rather than the event count hits the limit.
+	line := `{"webhook_id":"1","payload":"{}","api_key":"key"}` + strings.Repeat(" ", maxGatewayBodySize) + "\n"
+	body := strings.Repeat(line, maxGatewayStreamBodySize/len(line)+1)
+	rec := serveGateway(gateway, http.MethodPost, "/v1/events/stream", body)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestGateway_ServesOpenAPIDocument(t *testing.T) {
+	gateway := newTestGateway(t, &apiMock{})
+
+	rec := serveGateway(gateway, http.MethodGet, "/openapi.json", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var doc struct {
+		Paths      map[string]map[string]struct{ OperationID string } `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("expected JSON document, got %v", err)
+	}
+	for _, rt := range gatewayRoutes(&serverAPI{}) {
+		op := doc.Paths[rt.path][strings.ToLower(rt.method)]
+		if op.OperationID != string(rt.rpc) {
+			t.Fatalf("expected operation %s at %s %s, got %q", rt.rpc, rt.method, rt.path, op.OperationID)
+		}
+	}
+	for _, name := range []string{"Webhook", "RetryPolicy", "SubmitEventRequest", "Status"} {
+		if doc.Components.Schemas[name] == nil {
+			t.Fatalf("expected schema %s", name)
+		}
+	}
+}
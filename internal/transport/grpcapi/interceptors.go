@@ -0,0 +1,210 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDHeader is the metadata key, and the HTTP header of the gateway,
+// that carries the ID of a request.
+const requestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds request IDs taken from clients.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID returns the ID assigned to the request handled with ctx, or an
+// empty string outside of a request.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// UnaryInterceptors returns the interceptors every unary RPC runs through, in
+// order: request IDs, access logs, panic recovery and deadline enforcement.
+// Each RPC must complete within timeout; an earlier client deadline still
+// applies.
+func UnaryInterceptors(log *slog.Logger, timeout time.Duration) []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(withRequestID(ctx), req)
+		},
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			start := time.Now()
+			resp, err := handler(ctx, req)
+			logAccess(ctx, log, info.FullMethod, start, err)
+			return resp, err
+		},
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+			defer recoverPanic(log, info.FullMethod, &err)
+			return handler(ctx, req)
+		},
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := ctx.Err(); err != nil {
+				return nil, status.FromContextError(err).Err()
+			}
+			resp, err := handler(ctx, req)
+			return resp, deadlineError(ctx, err)
+		},
+	}
+}
+
+// StreamInterceptors returns the stream counterparts of UnaryInterceptors.
+// Client-streaming RPCs are not bound by timeout: they last as long as the
+// client keeps sending, and a client deadline still applies.
+func StreamInterceptors(log *slog.Logger, timeout time.Duration) []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &contextStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+		},
+		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+			err := handler(srv, ss)
+			logAccess(ss.Context(), log, info.FullMethod, start, err)
+			return err
+		},
+		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+			defer recoverPanic(log, info.FullMethod, &err)
+			return handler(srv, ss)
+		},
+		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if info.IsClientStream {
+				return handler(srv, ss)
+			}
+			ctx, cancel := context.WithTimeout(ss.Context(), timeout)
+			defer cancel()
+			if err := ctx.Err(); err != nil {
+				return status.FromContextError(err).Err()
+			}
+			err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+			return deadlineError(ctx, err)
+		},
+	}
+}
+
+// chainUnary combines interceptors into one that runs them in order, as
+// grpc.ChainUnaryInterceptor does for the server.
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// chainStream is the stream counterpart of chainUnary.
+func chainStream(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// withRequestID stores the request ID sent by the client, or a new one when
+// it sent none or an unusable one, in ctx and returns it to the client in the
+// response headers.
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 && validRequestID(values[0]) {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+
+	// Fails outside of a gRPC call, where the gateway sets the header itself.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, id))
+
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func logAccess(ctx context.Context, log *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	attrs := []any{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("request_id", RequestID(ctx)),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable:
+		log.Error("request failed", append(attrs, slog.String("error", err.Error()))...)
+	default:
+		log.Info("request handled", attrs...)
+	}
+}
+
+// recoverPanic turns a panic of a handler into an Internal error.
+func recoverPanic(log *slog.Logger, method string, err *error) {
+	if r := recover(); r != nil {
+		log.Error("panic while handling request", "method", method, "panic", r, "stack", string(debug.Stack()))
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+// deadlineError reports a handler failure caused by the request deadline as
+// DeadlineExceeded rather than as the error the interrupted work returned.
+// Handlers that already report DeadlineExceeded keep their message.
+func deadlineError(ctx context.Context, err error) error {
+	if err != nil && status.Code(err) != codes.DeadlineExceeded && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	}
+	return err
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,117 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func runUnary(ctx context.Context, timeout time.Duration, handler grpc.UnaryHandler) (any, error) {
+	chain := chainUnary(UnaryInterceptors(slog.New(slog.NewTextHandler(io.Discard, nil)), timeout))
+	return chain(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/hookify.Hookify/GetWebhook"}, handler)
+}
+
+func TestUnaryInterceptors_KeepsClientRequestID(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-1"))
+
+	var got string
+	_, err := runUnary(ctx, time.Minute, func(ctx context.Context, req any) (any, error) {
+		got = RequestID(ctx)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if got != "req-1" {
+		t.Fatalf("expected request ID req-1, got %q", got)
+	}
+}
+
+func TestUnaryInterceptors_GeneratesRequestID(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "bad id\n"))
+
+	var got string
+	_, _ = runUnary(ctx, time.Minute, func(ctx context.Context, req any) (any, error) {
+		got = RequestID(ctx)
+		return nil, nil
+	})
+	if len(got) != 32 {
+		t.Fatalf("expected a generated request ID, got %q", got)
+	}
+}
+
+func TestUnaryInterceptors_RecoversPanic(t *testing.T) {
+	_, err := runUnary(context.Background(), time.Minute, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal, got %v", err)
+	}
+}
+
+func TestUnaryInterceptors_EnforcesDeadline(t *testing.T) {
+	var deadline time.Time
+	_, err := runUnary(context.Background(), 10*time.Millisecond, func(ctx context.Context, req any) (any, error) {
+		deadline, _ = ctx.Deadline()
+		<-ctx.Done()
+		return nil, errors.New("query interrupted")
+	})
+	if deadline.IsZero() {
+		t.Fatalf("expected the handler to get a deadline")
+	}
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestStreamInterceptors_RecoversPanic(t *testing.T) {
+	chain := chainStream(StreamInterceptors(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Minute))
+	stream := &gatewayStream{ctx: context.Background()}
+
+	err := chain(nil, stream, &grpc.StreamServerInfo{FullMethod: "/hookify.Hookify/SubmitEventStream"}, func(srv any, ss grpc.ServerStream) error {
+		if RequestID(ss.Context()) == "" {
+			t.Errorf("expected a request ID on the stream context")
+		}
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal, got %v", err)
+	}
+}
+
+func TestStreamInterceptors_ClientStreamHasNoDeadline(t *testing.T) {
+	chain := chainStream(StreamInterceptors(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Millisecond))
+	stream := &gatewayStream{ctx: context.Background()}
+
+	err := chain(nil, stream, &grpc.StreamServerInfo{FullMethod: "/hookify.Hookify/SubmitEventStream", IsClientStream: true}, func(srv any, ss grpc.ServerStream) error {
+		time.Sleep(5 * time.Millisecond)
+		if _, ok := ss.Context().Deadline(); ok {
+			t.Errorf("expected a client stream without a deadline")
+		}
+		return ss.Context().Err()
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestStreamInterceptors_EnforcesDeadline(t *testing.T) {
+	chain := chainStream(StreamInterceptors(slog.New(slog.NewTextHandler(io.Discard, nil)), 10*time.Millisecond))
+	stream := &gatewayStream{ctx: context.Background()}
+
+	err := chain(nil, stream, &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}, func(srv any, ss grpc.ServerStream) error {
+		<-ss.Context().Done()
+		return errors.New("stream interrupted")
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package grpcapi
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	pb "hookify/gen/hookify"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var pathWildcard = regexp.MustCompile(`\{(\w+)\}`)
+
+// hookifyService describes the Hookify RPCs the gateway exposes.
+func hookifyService() protoreflect.ServiceDescriptor {
+	return pb.File_hookify_proto.Services().ByName("Hookify")
+}
+
+// openAPIDocument generates an OpenAPI 3 document for routes from the
+// descriptors of their request and response messages, so it stays in step
+// with protos/hookify.proto without a separate build step.
+func openAPIDocument(routes []gatewayRoute) map[string]any {
+	schemas := map[string]any{
+		"Status": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "format": "int32"},
+				"message": map[string]any{"type": "string"},
+				"details": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+			},
+		},
+	}
+	paths := make(map[string]any)
+
+	service := hookifyService()
+	for _, rt := range routes {
+		method := service.Methods().ByName(rt.rpc)
+		input, output := method.Input(), method.Output()
+
+		var params []any
+		inPath := make(map[string]bool)
+		for _, m := range pathWildcard.FindAllStringSubmatch(rt.path, -1) {
+			inPath[m[1]] = true
+			params = append(params, map[string]any{
+				"name":     m[1],
+				"in":       "path",
+				"required": true,
+				"schema":   fieldSchema(input.Fields().ByName(protoreflect.Name(m[1])), schemas),
+			})
+		}
+
+		op := map[string]any{
+			"operationId": string(rt.rpc),
+			"tags":        []string{strings.Split(rt.path, "/")[2]},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     jsonContent(messageRef(output, schemas)),
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content":     jsonContent(map[string]any{"$ref": "#/components/schemas/Status"}),
+				},
+			},
+		}
+
+		switch {
+		case method.IsStreamingClient():
+			op["requestBody"] = map[string]any{
+				"required":    true,
+				"description": "A sequence of " + string(input.Name()) + " JSON objects, one per line.",
+				"content": map[string]any{
+					"application/x-ndjson": map[string]any{"schema": messageRef(input, schemas)},
+				},
+			}
+		case rt.method == http.MethodPost || rt.method == http.MethodPatch:
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content":  jsonContent(messageRef(input, schemas)),
+			}
+		default:
+			fields := input.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if inPath[string(fd.Name())] || !queryField(fd) {
+					continue
+				}
+				params = append(params, map[string]any{
+					"name":   string(fd.Name()),
+					"in":     "query",
+					"schema": fieldSchema(fd, schemas),
+				})
+			}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		item, _ := paths[rt.path].(map[string]any)
+		if item == nil {
+			item = make(map[string]any)
+			paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Hookify",
+			"version": "v1",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// queryField reports whether fd can be set from a query parameter.
+func queryField(fd protoreflect.FieldDescriptor) bool {
+	if fd.Kind() != protoreflect.MessageKind {
+		return true
+	}
+	return wellKnownSchema(fd.Message()) != nil
+}
+
+// messageRef returns a reference to the schema of md, adding the schemas of
+// md and the messages it uses to schemas.
+func messageRef(md protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	name := string(md.Name())
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+
+	props := make(map[string]any)
+	schemas[name] = map[string]any{"type": "object", "properties": props}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		props[string(fd.Name())] = fieldSchema(fd, schemas)
+	}
+
+	return ref
+}
+
+func fieldSchema(fd protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	schema := scalarSchema(fd, schemas)
+	if fd.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+// scalarSchema follows the proto JSON mapping: 64-bit integers are strings,
+// enums are their value names.
+func scalarSchema(fd protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if schema := wellKnownSchema(fd.Message()); schema != nil {
+			return schema
+		}
+		return messageRef(fd.Message(), schemas)
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func wellKnownSchema(md protoreflect.MessageDescriptor) map[string]any {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return map[string]any{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`, "example": "30s"}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package grpcapi
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+func normalizePageSize(pageSize int32) (int, error) {
+	switch {
+	case pageSize < 0:
+		return 0, status.Error(codes.InvalidArgument, "page_size must be >= 0")
+	case pageSize == 0:
+		return defaultPageSize, nil
+	case pageSize > maxPageSize:
+		return maxPageSize, nil
+	default:
+		return int(pageSize), nil
+	}
+}
+
+// encodePageToken turns the last returned ID into an opaque cursor for the next page.
+func encodePageToken(lastID int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(lastID, 10)))
+}
+
+func decodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id < 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	return id, nil
+}
@@ -3,8 +3,11 @@ package grpcapi
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
+	"math"
 	"net/url"
+	"time"
 
 	pb "hookify/gen/hookify"
 	"hookify/internal/models"
@@ -13,68 +16,1074 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxIdempotencyKeyLength matches the width of the idempotency_keys.key column.
+const maxIdempotencyKeyLength = 255
+
+// maxApplicationNameLength matches the width of the applications.name column.
+const maxApplicationNameLength = 255
+
+// maxSubmitBatchSize bounds how many events are stored in one transaction.
+const maxSubmitBatchSize = 500
+
+// maxSubmitStreamSize bounds how many events one stream may submit, since a
+// result is kept for each of them until the stream ends.
+const maxSubmitStreamSize = 20 * maxSubmitBatchSize
+
 type WebhookAPI interface {
-	CreateWebhook(ctx context.Context, url string) (webhookID int64, secret string, err error)
-	SubmitEvent(ctx context.Context, webhookID int64, payload string, secret string) (eventID int64, err error)
+	CreateWebhook(ctx context.Context, url string, applicationID int64) (webhookID int64, secret string, apiKey string, err error)
+	GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error)
+	ListWebhooks(ctx context.Context, afterID int64, limit int) ([]models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID int64, update models.WebhookUpdate) (models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	PauseWebhook(ctx context.Context, webhookID int64) (models.Webhook, error)
+	ResumeWebhook(ctx context.Context, webhookID int64) (models.Webhook, error)
+	RotateWebhookSecret(ctx context.Context, webhookID int64, gracePeriod *time.Duration) (secret string, previousExpiresAt time.Time, err error)
+	RotateWebhookAPIKey(ctx context.Context, webhookID int64, gracePeriod *time.Duration) (apiKey string, previousExpiresAt time.Time, err error)
+	SubmitEvent(ctx context.Context, webhookID int64, eventType string, payload string, apiKey string, idempotencyKey string) (eventID int64, created bool, err error)
+	SubmitEvents(ctx context.Context, submissions []models.EventSubmission) ([]models.SubmissionResult, error)
+	GetEvent(ctx context.Context, eventID int64) (models.RawEvent, error)
+	ListEvents(ctx context.Context, filter models.EventFilter, beforeID int64, limit int) ([]models.RawEvent, error)
+	ListDeliveryAttempts(ctx context.Context, eventID int64, afterID int64, limit int) ([]models.DeliveryAttempt, error)
+	RedeliverEvent(ctx context.Context, eventID int64) error
+	ReplayEvents(ctx context.Context, filter models.EventFilter, ratePerSecond float64) (scheduled int, err error)
+	ListDeadLetters(ctx context.Context, filter models.DeadLetterFilter, beforeID int64, limit int) ([]models.DeadLetter, error)
+	RequeueDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error)
+	PurgeDeadLetters(ctx context.Context, filter models.DeadLetterFilter) (int64, error)
+	CreateApplication(ctx context.Context, name string) (applicationID int64, apiKey string, err error)
+	GetApplication(ctx context.Context, applicationID int64) (models.Application, error)
+	DeleteApplication(ctx context.Context, applicationID int64) error
+	PublishMessage(ctx context.Context, applicationID int64, eventType string, payload string, apiKey string) (models.Message, []models.RawEvent, error)
+	GetMessage(ctx context.Context, messageID int64) (models.Message, error)
 }
 
 type serverAPI struct {
 	pb.UnimplementedHookifyServer
-	webhookAPI WebhookAPI
-	log        *slog.Logger
+	webhookAPI   WebhookAPI
+	egressPolicy models.EgressPolicy
+	log          *slog.Logger
 }
 
-func Register(s *grpc.Server, api WebhookAPI, log *slog.Logger) {
-	pb.RegisterHookifyServer(s, &serverAPI{webhookAPI: api, log: log})
+// Register exposes api on s. Webhook URLs are checked against egressPolicy
+// when they are registered so obviously internal targets are refused early;
+// the delivery client enforces the policy again on every connection.
+func Register(s *grpc.Server, api WebhookAPI, egressPolicy models.EgressPolicy, log *slog.Logger) {
+	pb.RegisterHookifyServer(s, &serverAPI{webhookAPI: api, egressPolicy: egressPolicy, log: log})
 }
 
 func (s *serverAPI) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.CreateWebhookResponse, error) {
-	if req.Url == "" {
-		return nil, status.Error(codes.InvalidArgument, "url is required")
+	if err := s.validateURL(req.Url); err != nil {
+		return nil, err
 	}
 
-	_, err := url.ParseRequestURI(req.Url)
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid url")
+	if req.ApplicationId < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid application_id")
 	}
 
-	webhookID, secret, err := s.webhookAPI.CreateWebhook(ctx, req.Url)
+	webhookID, secret, apiKey, err := s.webhookAPI.CreateWebhook(ctx, req.Url, req.ApplicationId)
 	if err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return nil, status.Error(codes.NotFound, "application not found")
+		}
 		return nil, status.Error(codes.Internal, "failed to create webhook")
 	}
 
 	resp := &pb.CreateWebhookResponse{
 		WebhookId: webhookID,
 		Secret:    secret,
+		ApiKey:    apiKey,
 	}
 
 	return resp, nil
 }
 
-func (s *serverAPI) SubmitEvent(ctx context.Context, req *pb.SubmitEventRequest) (*pb.SubmitEventResponse, error) {
-	if req.Secret == "" {
-		return nil, status.Error(codes.InvalidArgument, "secret is required")
+func (s *serverAPI) GetWebhook(ctx context.Context, req *pb.GetWebhookRequest) (*pb.GetWebhookResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	webhook, err := s.webhookAPI.GetWebhook(ctx, req.WebhookId)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
+		}
+
+		s.log.Error("failed to get webhook", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get webhook")
+	}
+
+	return &pb.GetWebhookResponse{Webhook: toProtoWebhook(webhook)}, nil
+}
+
+func (s *serverAPI) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	pageSize, err := normalizePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookAPI.ListWebhooks(ctx, afterID, pageSize+1)
+	if err != nil {
+		s.log.Error("failed to list webhooks", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list webhooks")
+	}
+
+	resp := &pb.ListWebhooksResponse{}
+	if len(webhooks) > pageSize {
+		webhooks = webhooks[:pageSize]
+		resp.NextPageToken = encodePageToken(webhooks[pageSize-1].ID)
+	}
+	for _, webhook := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, toProtoWebhook(webhook))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) UpdateWebhook(ctx context.Context, req *pb.UpdateWebhookRequest) (*pb.UpdateWebhookResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	var update models.WebhookUpdate
+	if req.Url != nil {
+		if err := s.validateURL(*req.Url); err != nil {
+			return nil, err
+		}
+		update.URL = req.Url
+	}
+	if req.SignatureScheme != pb.SignatureScheme_SIGNATURE_SCHEME_UNSPECIFIED {
+		scheme, err := fromProtoSignatureScheme(req.SignatureScheme)
+		if err != nil {
+			return nil, err
+		}
+		// The legacy scheme sends the secret in plaintext; webhooks migrated
+		// with it may move to signed deliveries but never back.
+		if scheme == models.SignatureSchemeLegacy {
+			return nil, status.Error(codes.InvalidArgument, "signature_scheme can only be changed to SIGNATURE_SCHEME_STANDARD")
+		}
+		update.SignatureScheme = &scheme
+	}
+	if req.RetryPolicy != nil && req.ClearRetryPolicy {
+		return nil, status.Error(codes.InvalidArgument, "retry_policy and clear_retry_policy are mutually exclusive")
+	}
+	if req.RetryPolicy != nil {
+		policy, err := fromProtoRetryPolicy(req.RetryPolicy)
+		if err != nil {
+			return nil, err
+		}
+		update.RetryPolicy = &policy
+	}
+	update.ClearRetryPolicy = req.ClearRetryPolicy
+	update.Ordered = req.Ordered
+	if req.EventTypes != nil {
+		for _, pattern := range req.EventTypes.Patterns {
+			if !models.ValidEventTypePattern(pattern) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid event type pattern %q", pattern)
+			}
+		}
+		patterns := req.EventTypes.Patterns
+		update.EventTypes = &patterns
 	}
 
-	eventID, err := s.webhookAPI.SubmitEvent(ctx, req.WebhookId, req.Payload, req.Secret)
+	webhook, err := s.webhookAPI.UpdateWebhook(ctx, req.WebhookId, update)
 	if err != nil {
 		if errors.Is(err, models.ErrWebhookNotFound) {
 			return nil, status.Error(codes.NotFound, "webhook not found")
 		}
-		if errors.Is(err, hookify.ErrInvalidWebhookSecret) {
-			return nil, status.Error(codes.Unauthenticated, "invalid webhook secret")
+
+		s.log.Error("failed to update webhook", "error", err)
+		return nil, status.Error(codes.Internal, "failed to update webhook")
+	}
+
+	return &pb.UpdateWebhookResponse{Webhook: toProtoWebhook(webhook)}, nil
+}
+
+func (s *serverAPI) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	if err := s.webhookAPI.DeleteWebhook(ctx, req.WebhookId); err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
+		}
+
+		s.log.Error("failed to delete webhook", "error", err)
+		return nil, status.Error(codes.Internal, "failed to delete webhook")
+	}
+
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+func (s *serverAPI) PauseWebhook(ctx context.Context, req *pb.PauseWebhookRequest) (*pb.PauseWebhookResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	webhook, err := s.webhookAPI.PauseWebhook(ctx, req.WebhookId)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
+		}
+
+		s.log.Error("failed to pause webhook", "error", err)
+		return nil, status.Error(codes.Internal, "failed to pause webhook")
+	}
+
+	return &pb.PauseWebhookResponse{Webhook: toProtoWebhook(webhook)}, nil
+}
+
+func (s *serverAPI) ResumeWebhook(ctx context.Context, req *pb.ResumeWebhookRequest) (*pb.ResumeWebhookResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	webhook, err := s.webhookAPI.ResumeWebhook(ctx, req.WebhookId)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
+		}
+
+		s.log.Error("failed to resume webhook", "error", err)
+		return nil, status.Error(codes.Internal, "failed to resume webhook")
+	}
+
+	return &pb.ResumeWebhookResponse{Webhook: toProtoWebhook(webhook)}, nil
+}
+
+func (s *serverAPI) RotateWebhookSecret(ctx context.Context, req *pb.RotateWebhookSecretRequest) (*pb.RotateWebhookSecretResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	gracePeriod, err := parseGracePeriod(req.GracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, previousExpiresAt, err := s.webhookAPI.RotateWebhookSecret(ctx, req.WebhookId, gracePeriod)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
+		}
+
+		s.log.Error("failed to rotate webhook secret", "error", err)
+		return nil, status.Error(codes.Internal, "failed to rotate webhook secret")
+	}
+
+	resp := &pb.RotateWebhookSecretResponse{
+		Secret:                  secret,
+		PreviousSecretExpiresAt: timestamppb.New(previousExpiresAt),
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) RotateWebhookApiKey(ctx context.Context, req *pb.RotateWebhookApiKeyRequest) (*pb.RotateWebhookApiKeyResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+
+	gracePeriod, err := parseGracePeriod(req.GracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, previousExpiresAt, err := s.webhookAPI.RotateWebhookAPIKey(ctx, req.WebhookId, gracePeriod)
+	if err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
 		}
 
-		s.log.Error("failed to submit event", "error", err)
-		return nil, status.Error(codes.Internal, "failed to submit event")
+		s.log.Error("failed to rotate webhook api key", "error", err)
+		return nil, status.Error(codes.Internal, "failed to rotate webhook api key")
+	}
+
+	resp := &pb.RotateWebhookApiKeyResponse{
+		ApiKey:                  apiKey,
+		PreviousApiKeyExpiresAt: timestamppb.New(previousExpiresAt),
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) SubmitEvent(ctx context.Context, req *pb.SubmitEventRequest) (*pb.SubmitEventResponse, error) {
+	if err := validateSubmitEvent(req); err != nil {
+		return nil, err
+	}
+
+	eventID, created, err := s.webhookAPI.SubmitEvent(ctx, req.WebhookId, req.EventType, req.Payload, req.ApiKey, req.IdempotencyKey)
+	if err != nil {
+		return nil, s.submitEventError(err)
 	}
 
 	resp := &pb.SubmitEventResponse{
 		EventId: eventID,
-		Created: true,
+		Created: created,
 	}
 
 	return resp, nil
 }
+
+func (s *serverAPI) SubmitEvents(ctx context.Context, req *pb.SubmitEventsRequest) (*pb.SubmitEventsResponse, error) {
+	if len(req.Events) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "events is required")
+	}
+	if len(req.Events) > maxSubmitBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d events can be submitted at once", maxSubmitBatchSize)
+	}
+
+	results, err := s.submitBatch(ctx, req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SubmitEventsResponse{Results: results}, nil
+}
+
+// SubmitEventStream stores the streamed events in batches of
+// maxSubmitBatchSize as they arrive and reports every result once the client
+// closes the stream. A stream may carry at most maxSubmitStreamSize events.
+// When the stream fails, earlier batches may already be stored; resending
+// them with idempotency keys does not duplicate them.
+func (s *serverAPI) SubmitEventStream(stream pb.Hookify_SubmitEventStreamServer) error {
+	var (
+		results  []*pb.SubmitEventResult
+		batch    []*pb.SubmitEventRequest
+		received int
+	)
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		received++
+		if received > maxSubmitStreamSize {
+			return status.Errorf(codes.ResourceExhausted, "at most %d events can be submitted in one stream", maxSubmitStreamSize)
+		}
+		batch = append(batch, req)
+		if len(batch) < maxSubmitBatchSize {
+			continue
+		}
+		batchResults, err := s.submitBatch(stream.Context(), batch)
+		if err != nil {
+			return err
+		}
+		results = append(results, batchResults...)
+		batch = batch[:0]
+	}
+
+	if len(batch) > 0 {
+		batchResults, err := s.submitBatch(stream.Context(), batch)
+		if err != nil {
+			return err
+		}
+		results = append(results, batchResults...)
+	}
+
+	return stream.SendAndClose(&pb.SubmitEventsResponse{Results: results})
+}
+
+// submitBatch submits the events in one call and reports a result per event.
+// Events that fail validation are reported without being submitted.
+func (s *serverAPI) submitBatch(ctx context.Context, reqs []*pb.SubmitEventRequest) ([]*pb.SubmitEventResult, error) {
+	var (
+		results     = make([]*pb.SubmitEventResult, len(reqs))
+		submissions []models.EventSubmission
+		indexes     []int
+	)
+	for i, req := range reqs {
+		if err := validateSubmitEvent(req); err != nil {
+			results[i] = toProtoSubmitEventResult(models.SubmissionResult{}, err)
+			continue
+		}
+		submissions = append(submissions, models.EventSubmission{
+			WebhookID:      req.WebhookId,
+			EventType:      req.EventType,
+			Payload:        req.Payload,
+			APIKey:         req.ApiKey,
+			IdempotencyKey: req.IdempotencyKey,
+		})
+		indexes = append(indexes, i)
+	}
+
+	if len(submissions) == 0 {
+		return results, nil
+	}
+
+	saved, err := s.webhookAPI.SubmitEvents(ctx, submissions)
+	if err != nil {
+		s.log.Error("failed to submit events", "error", err)
+		return nil, status.Error(codes.Internal, "failed to submit events")
+	}
+	for n, i := range indexes {
+		var itemErr error
+		if saved[n].Err != nil {
+			itemErr = s.submitEventError(saved[n].Err)
+		}
+		results[i] = toProtoSubmitEventResult(saved[n], itemErr)
+	}
+
+	return results, nil
+}
+
+func validateSubmitEvent(req *pb.SubmitEventRequest) error {
+	if req.ApiKey == "" {
+		return status.Error(codes.InvalidArgument, "api_key is required")
+	}
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return status.Errorf(codes.InvalidArgument, "idempotency_key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	if req.EventType != "" && !models.ValidEventType(req.EventType) {
+		return status.Error(codes.InvalidArgument, "invalid event_type")
+	}
+	return nil
+}
+
+func (s *serverAPI) submitEventError(err error) error {
+	if errors.Is(err, models.ErrWebhookNotFound) {
+		return status.Error(codes.NotFound, "webhook not found")
+	}
+	if errors.Is(err, hookify.ErrInvalidAPIKey) {
+		return status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	if errors.Is(err, hookify.ErrWebhookDisabled) {
+		return status.Error(codes.FailedPrecondition, "webhook is disabled")
+	}
+	if errors.Is(err, hookify.ErrInvalidPayload) {
+		return status.Error(codes.InvalidArgument, "payload is not valid JSON")
+	}
+
+	s.log.Error("failed to submit event", "error", err)
+	return status.Error(codes.Internal, "failed to submit event")
+}
+
+// toProtoSubmitEventResult converts a batch result; err is the gRPC status
+// error the item was rejected with, if any.
+func toProtoSubmitEventResult(result models.SubmissionResult, err error) *pb.SubmitEventResult {
+	if err != nil {
+		st := status.Convert(err)
+		return &pb.SubmitEventResult{ErrorCode: int32(st.Code()), ErrorMessage: st.Message()}
+	}
+	return &pb.SubmitEventResult{EventId: result.EventID, Created: result.Created}
+}
+
+func (s *serverAPI) CreateApplication(ctx context.Context, req *pb.CreateApplicationRequest) (*pb.CreateApplicationResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.Name) > maxApplicationNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxApplicationNameLength)
+	}
+
+	applicationID, apiKey, err := s.webhookAPI.CreateApplication(ctx, req.Name)
+	if err != nil {
+		s.log.Error("failed to create application", "error", err)
+		return nil, status.Error(codes.Internal, "failed to create application")
+	}
+
+	return &pb.CreateApplicationResponse{ApplicationId: applicationID, ApiKey: apiKey}, nil
+}
+
+func (s *serverAPI) GetApplication(ctx context.Context, req *pb.GetApplicationRequest) (*pb.GetApplicationResponse, error) {
+	if req.ApplicationId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "application_id is required")
+	}
+
+	app, err := s.webhookAPI.GetApplication(ctx, req.ApplicationId)
+	if err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return nil, status.Error(codes.NotFound, "application not found")
+		}
+
+		s.log.Error("failed to get application", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get application")
+	}
+
+	return &pb.GetApplicationResponse{Application: toProtoApplication(app)}, nil
+}
+
+func (s *serverAPI) DeleteApplication(ctx context.Context, req *pb.DeleteApplicationRequest) (*pb.DeleteApplicationResponse, error) {
+	if req.ApplicationId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "application_id is required")
+	}
+
+	if err := s.webhookAPI.DeleteApplication(ctx, req.ApplicationId); err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return nil, status.Error(codes.NotFound, "application not found")
+		}
+
+		s.log.Error("failed to delete application", "error", err)
+		return nil, status.Error(codes.Internal, "failed to delete application")
+	}
+
+	return &pb.DeleteApplicationResponse{}, nil
+}
+
+func (s *serverAPI) PublishMessage(ctx context.Context, req *pb.PublishMessageRequest) (*pb.PublishMessageResponse, error) {
+	if req.ApplicationId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "application_id is required")
+	}
+	if req.ApiKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "api_key is required")
+	}
+	if req.EventType != "" && !models.ValidEventType(req.EventType) {
+		return nil, status.Error(codes.InvalidArgument, "invalid event_type")
+	}
+
+	message, events, err := s.webhookAPI.PublishMessage(ctx, req.ApplicationId, req.EventType, req.Payload, req.ApiKey)
+	if err != nil {
+		if errors.Is(err, models.ErrApplicationNotFound) {
+			return nil, status.Error(codes.NotFound, "application not found")
+		}
+		if errors.Is(err, hookify.ErrInvalidAPIKey) {
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		if errors.Is(err, hookify.ErrInvalidPayload) {
+			return nil, status.Error(codes.InvalidArgument, "payload is not valid JSON")
+		}
+
+		s.log.Error("failed to publish message", "error", err)
+		return nil, status.Error(codes.Internal, "failed to publish message")
+	}
+
+	resp := &pb.PublishMessageResponse{MessageId: message.ID}
+	for _, event := range events {
+		resp.Deliveries = append(resp.Deliveries, toProtoEvent(event))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) GetMessage(ctx context.Context, req *pb.GetMessageRequest) (*pb.GetMessageResponse, error) {
+	if req.MessageId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "message_id is required")
+	}
+
+	message, err := s.webhookAPI.GetMessage(ctx, req.MessageId)
+	if err != nil {
+		if errors.Is(err, models.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+
+		s.log.Error("failed to get message", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get message")
+	}
+
+	return &pb.GetMessageResponse{Message: toProtoMessage(message)}, nil
+}
+
+func (s *serverAPI) GetEvent(ctx context.Context, req *pb.GetEventRequest) (*pb.GetEventResponse, error) {
+	if req.EventId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	event, err := s.webhookAPI.GetEvent(ctx, req.EventId)
+	if err != nil {
+		if errors.Is(err, models.ErrEventNotFound) {
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+
+		s.log.Error("failed to get event", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get event")
+	}
+
+	return &pb.GetEventResponse{Event: toProtoEvent(event)}, nil
+}
+
+func (s *serverAPI) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	filter, err := eventFilterFromRequest(req.WebhookId, req.Status, req.CreatedAfter, req.CreatedBefore)
+	if err != nil {
+		return nil, err
+	}
+	if req.MessageId < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid message_id")
+	}
+	filter.MessageID = req.MessageId
+
+	pageSize, err := normalizePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeID, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.webhookAPI.ListEvents(ctx, filter, beforeID, pageSize+1)
+	if err != nil {
+		s.log.Error("failed to list events", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list events")
+	}
+
+	resp := &pb.ListEventsResponse{}
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		resp.NextPageToken = encodePageToken(events[pageSize-1].ID)
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, toProtoEvent(event))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) ListDeliveryAttempts(ctx context.Context, req *pb.ListDeliveryAttemptsRequest) (*pb.ListDeliveryAttemptsResponse, error) {
+	if req.EventId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	pageSize, err := normalizePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.webhookAPI.ListDeliveryAttempts(ctx, req.EventId, afterID, pageSize+1)
+	if err != nil {
+		s.log.Error("failed to list delivery attempts", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list delivery attempts")
+	}
+
+	resp := &pb.ListDeliveryAttemptsResponse{}
+	if len(attempts) > pageSize {
+		attempts = attempts[:pageSize]
+		resp.NextPageToken = encodePageToken(attempts[pageSize-1].ID)
+	}
+	for _, attempt := range attempts {
+		resp.Attempts = append(resp.Attempts, toProtoDeliveryAttempt(attempt))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) RedeliverEvent(ctx context.Context, req *pb.RedeliverEventRequest) (*pb.RedeliverEventResponse, error) {
+	if req.EventId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	if err := s.webhookAPI.RedeliverEvent(ctx, req.EventId); err != nil {
+		if errors.Is(err, models.ErrEventNotFound) {
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+
+		s.log.Error("failed to redeliver event", "error", err)
+		return nil, status.Error(codes.Internal, "failed to redeliver event")
+	}
+
+	return &pb.RedeliverEventResponse{}, nil
+}
+
+func (s *serverAPI) ReplayEvents(ctx context.Context, req *pb.ReplayEventsRequest) (*pb.ReplayEventsResponse, error) {
+	if req.WebhookId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+	if req.RatePerSecond != 0 && (req.RatePerSecond < models.MinReplayRate || req.RatePerSecond > models.MaxReplayRate || math.IsNaN(req.RatePerSecond)) {
+		return nil, status.Errorf(codes.InvalidArgument, "rate_per_second must be 0 for the default or between %g and %g", models.MinReplayRate, models.MaxReplayRate)
+	}
+
+	filter, err := eventFilterFromRequest(req.WebhookId, req.Status, req.CreatedAfter, req.CreatedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := s.webhookAPI.ReplayEvents(ctx, filter, req.RatePerSecond)
+	if err != nil {
+		s.log.Error("failed to replay events", "error", err, "scheduled", scheduled)
+		code := codes.Internal
+		if ctx.Err() != nil {
+			code = status.FromContextError(ctx.Err()).Code()
+		}
+		// Queued events are skipped when the replay is run again.
+		return nil, status.Errorf(code, "replay stopped after scheduling %d events; run it again to resume", scheduled)
+	}
+
+	return &pb.ReplayEventsResponse{Scheduled: int64(scheduled)}, nil
+}
+
+func (s *serverAPI) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	filter, err := deadLetterFilterFromRequest(req.WebhookId, req.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize, err := normalizePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeID, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters, err := s.webhookAPI.ListDeadLetters(ctx, filter, beforeID, pageSize+1)
+	if err != nil {
+		s.log.Error("failed to list dead letters", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list dead letters")
+	}
+
+	resp := &pb.ListDeadLettersResponse{}
+	if len(deadLetters) > pageSize {
+		deadLetters = deadLetters[:pageSize]
+		resp.NextPageToken = encodePageToken(deadLetters[pageSize-1].ID)
+	}
+	for _, deadLetter := range deadLetters {
+		resp.DeadLetters = append(resp.DeadLetters, toProtoDeadLetter(deadLetter))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) RequeueDeadLetter(ctx context.Context, req *pb.RequeueDeadLetterRequest) (*pb.RequeueDeadLetterResponse, error) {
+	if req.DeadLetterId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "dead_letter_id is required")
+	}
+
+	deadLetter, err := s.webhookAPI.RequeueDeadLetter(ctx, req.DeadLetterId)
+	if err != nil {
+		if errors.Is(err, models.ErrDeadLetterNotFound) {
+			return nil, status.Error(codes.NotFound, "dead letter not found")
+		}
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return nil, status.Error(codes.NotFound, "webhook not found")
+		}
+		if errors.Is(err, models.ErrEventNotFound) {
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		if errors.Is(err, hookify.ErrDeadLetterNotRequeueable) {
+			return nil, status.Error(codes.FailedPrecondition, "dead letter cannot be requeued")
+		}
+
+		s.log.Error("failed to requeue dead letter", "error", err)
+		return nil, status.Error(codes.Internal, "failed to requeue dead letter")
+	}
+
+	return &pb.RequeueDeadLetterResponse{EventId: deadLetter.EventID}, nil
+}
+
+func (s *serverAPI) PurgeDeadLetters(ctx context.Context, req *pb.PurgeDeadLettersRequest) (*pb.PurgeDeadLettersResponse, error) {
+	filter, err := deadLetterFilterFromRequest(req.WebhookId, req.Source, req.CreatedBefore)
+	if err != nil {
+		return nil, err
+	}
+	if filter == (models.DeadLetterFilter{}) && !req.All {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id, source or created_before is required, or all to purge every dead letter")
+	}
+
+	purged, err := s.webhookAPI.PurgeDeadLetters(ctx, filter)
+	if err != nil {
+		s.log.Error("failed to purge dead letters", "error", err)
+		return nil, status.Error(codes.Internal, "failed to purge dead letters")
+	}
+
+	return &pb.PurgeDeadLettersResponse{Purged: purged}, nil
+}
+
+func (s *serverAPI) validateURL(rawURL string) error {
+	if rawURL == "" {
+		return status.Error(codes.InvalidArgument, "url is required")
+	}
+
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil || u.Host == "" {
+		return status.Error(codes.InvalidArgument, "invalid url")
+	}
+
+	if err := s.egressPolicy.CheckURL(u); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+func parseGracePeriod(d *durationpb.Duration) (*time.Duration, error) {
+	if d == nil {
+		return nil, nil
+	}
+	if err := d.CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid grace_period")
+	}
+	gracePeriod := d.AsDuration()
+	if gracePeriod < 0 {
+		return nil, status.Error(codes.InvalidArgument, "grace_period must be >= 0")
+	}
+	return &gracePeriod, nil
+}
+
+func toProtoWebhook(webhook models.Webhook) *pb.Webhook {
+	w := &pb.Webhook{
+		Id:              webhook.ID,
+		Url:             webhook.URL,
+		CreatedAt:       timestamppb.New(webhook.CreatedAt),
+		SignatureScheme: toProtoSignatureScheme(webhook.SignatureScheme),
+		Status:          toProtoWebhookStatus(webhook.Status),
+		DisabledReason:  webhook.DisabledReason,
+		Ordered:         webhook.Ordered,
+		EventTypes:      webhook.EventTypes,
+		ApplicationId:   webhook.ApplicationID,
+	}
+	if webhook.PreviousSecretExpiresAt != nil {
+		w.PreviousSecretExpiresAt = timestamppb.New(*webhook.PreviousSecretExpiresAt)
+	}
+	if webhook.PreviousAPIKeyExpiresAt != nil {
+		w.PreviousApiKeyExpiresAt = timestamppb.New(*webhook.PreviousAPIKeyExpiresAt)
+	}
+	if webhook.RetryPolicy != nil {
+		w.RetryPolicy = toProtoRetryPolicy(*webhook.RetryPolicy)
+	}
+	return w
+}
+
+func fromProtoRetryPolicy(p *pb.RetryPolicy) (models.RetryPolicy, error) {
+	for _, d := range []*durationpb.Duration{p.InitialInterval, p.MaxInterval, p.MaxAge} {
+		if d != nil && d.CheckValid() != nil {
+			return models.RetryPolicy{}, status.Error(codes.InvalidArgument, "invalid retry_policy duration")
+		}
+	}
+
+	policy := models.RetryPolicy{
+		InitialInterval: p.InitialInterval.AsDuration(),
+		Multiplier:      p.Multiplier,
+		MaxInterval:     p.MaxInterval.AsDuration(),
+		Jitter:          p.Jitter,
+		MaxAttempts:     int(p.MaxAttempts),
+		MaxAge:          p.MaxAge.AsDuration(),
+	}
+	if err := policy.Validate(); err != nil {
+		return models.RetryPolicy{}, status.Error(codes.InvalidArgument, "invalid retry_policy: "+err.Error())
+	}
+
+	return policy, nil
+}
+
+func toProtoRetryPolicy(p models.RetryPolicy) *pb.RetryPolicy {
+	return &pb.RetryPolicy{
+		InitialInterval: durationpb.New(p.InitialInterval),
+		Multiplier:      p.Multiplier,
+		MaxInterval:     durationpb.New(p.MaxInterval),
+		Jitter:          p.Jitter,
+		MaxAttempts:     int32(p.MaxAttempts),
+		MaxAge:          durationpb.New(p.MaxAge),
+	}
+}
+
+func fromProtoSignatureScheme(scheme pb.SignatureScheme) (models.SignatureScheme, error) {
+	switch scheme {
+	case pb.SignatureScheme_SIGNATURE_SCHEME_STANDARD:
+		return models.SignatureSchemeStandard, nil
+	case pb.SignatureScheme_SIGNATURE_SCHEME_LEGACY:
+		return models.SignatureSchemeLegacy, nil
+	default:
+		return "", status.Error(codes.InvalidArgument, "invalid signature_scheme")
+	}
+}
+
+func toProtoSignatureScheme(scheme models.SignatureScheme) pb.SignatureScheme {
+	switch scheme {
+	case models.SignatureSchemeStandard:
+		return pb.SignatureScheme_SIGNATURE_SCHEME_STANDARD
+	case models.SignatureSchemeLegacy:
+		return pb.SignatureScheme_SIGNATURE_SCHEME_LEGACY
+	default:
+		return pb.SignatureScheme_SIGNATURE_SCHEME_UNSPECIFIED
+	}
+}
+
+func toProtoWebhookStatus(webhookStatus models.WebhookStatus) pb.WebhookStatus {
+	switch webhookStatus {
+	case models.WebhookStatusActive:
+		return pb.WebhookStatus_WEBHOOK_STATUS_ACTIVE
+	case models.WebhookStatusDisabled:
+		return pb.WebhookStatus_WEBHOOK_STATUS_DISABLED
+	case models.WebhookStatusPaused:
+		return pb.WebhookStatus_WEBHOOK_STATUS_PAUSED
+	default:
+		return pb.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+	}
+}
+
+func eventFilterFromRequest(webhookID int64, eventStatus pb.EventStatus, createdAfter, createdBefore *timestamppb.Timestamp) (models.EventFilter, error) {
+	if webhookID < 0 {
+		return models.EventFilter{}, status.Error(codes.InvalidArgument, "invalid webhook_id")
+	}
+	filter := models.EventFilter{WebhookID: webhookID}
+
+	if eventStatus != pb.EventStatus_EVENT_STATUS_UNSPECIFIED {
+		st, err := fromProtoEventStatus(eventStatus)
+		if err != nil {
+			return models.EventFilter{}, err
+		}
+		filter.Status = st
+	}
+
+	if createdAfter != nil {
+		if err := createdAfter.CheckValid(); err != nil {
+			return models.EventFilter{}, status.Error(codes.InvalidArgument, "invalid created_after")
+		}
+		filter.CreatedAfter = createdAfter.AsTime()
+	}
+	if createdBefore != nil {
+		if err := createdBefore.CheckValid(); err != nil {
+			return models.EventFilter{}, status.Error(codes.InvalidArgument, "invalid created_before")
+		}
+		filter.CreatedBefore = createdBefore.AsTime()
+	}
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedAfter.Before(filter.CreatedBefore) {
+		return models.EventFilter{}, status.Error(codes.InvalidArgument, "created_after must be before created_before")
+	}
+
+	return filter, nil
+}
+
+func toProtoEvent(event models.RawEvent) *pb.Event {
+	return &pb.Event{
+		Id:        event.ID,
+		WebhookId: event.WebhookID,
+		MessageId: event.MessageID,
+		EventType: event.EventType,
+		Sequence:  event.Sequence,
+		Payload:   event.Payload,
+		Status:    toProtoEventStatus(event.Status),
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+}
+
+func toProtoApplication(app models.Application) *pb.Application {
+	return &pb.Application{
+		Id:        app.ID,
+		Name:      app.Name,
+		CreatedAt: timestamppb.New(app.CreatedAt),
+	}
+}
+
+func toProtoMessage(message models.Message) *pb.Message {
+	return &pb.Message{
+		Id:            message.ID,
+		ApplicationId: message.ApplicationID,
+		EventType:     message.EventType,
+		Payload:       message.Payload,
+		CreatedAt:     timestamppb.New(message.CreatedAt),
+	}
+}
+
+func toProtoDeliveryAttempt(attempt models.DeliveryAttempt) *pb.DeliveryAttempt {
+	return &pb.DeliveryAttempt{
+		Id:           attempt.ID,
+		EventId:      attempt.EventID,
+		WebhookId:    attempt.WebhookID,
+		Attempt:      int32(attempt.Attempt),
+		RequestedAt:  timestamppb.New(attempt.RequestedAt),
+		StatusCode:   int32(attempt.StatusCode),
+		ResponseBody: attempt.ResponseBody,
+		Latency:      durationpb.New(attempt.Latency),
+		Error:        attempt.Error,
+	}
+}
+
+func fromProtoEventStatus(st pb.EventStatus) (models.EventStatus, error) {
+	switch st {
+	case pb.EventStatus_EVENT_STATUS_PENDING:
+		return models.EventStatusPending, nil
+	case pb.EventStatus_EVENT_STATUS_DELIVERED:
+		return models.EventStatusDelivered, nil
+	case pb.EventStatus_EVENT_STATUS_FAILED:
+		return models.EventStatusFailed, nil
+	case pb.EventStatus_EVENT_STATUS_FILTERED:
+		return models.EventStatusFiltered, nil
+	default:
+		return "", status.Error(codes.InvalidArgument, "invalid status")
+	}
+}
+
+func toProtoEventStatus(st models.EventStatus) pb.EventStatus {
+	switch st {
+	case models.EventStatusPending:
+		return pb.EventStatus_EVENT_STATUS_PENDING
+	case models.EventStatusDelivered:
+		return pb.EventStatus_EVENT_STATUS_DELIVERED
+	case models.EventStatusFailed:
+		return pb.EventStatus_EVENT_STATUS_FAILED
+	case models.EventStatusFiltered:
+		return pb.EventStatus_EVENT_STATUS_FILTERED
+	default:
+		return pb.EventStatus_EVENT_STATUS_UNSPECIFIED
+	}
+}
+
+func deadLetterFilterFromRequest(webhookID int64, source pb.DeadLetterSource, createdBefore *timestamppb.Timestamp) (models.DeadLetterFilter, error) {
+	if webhookID < 0 {
+		return models.DeadLetterFilter{}, status.Error(codes.InvalidArgument, "invalid webhook_id")
+	}
+	filter := models.DeadLetterFilter{WebhookID: webhookID}
+
+	if source != pb.DeadLetterSource_DEAD_LETTER_SOURCE_UNSPECIFIED {
+		src, err := fromProtoDeadLetterSource(source)
+		if err != nil {
+			return models.DeadLetterFilter{}, err
+		}
+		filter.Source = src
+	}
+
+	if createdBefore != nil {
+		if err := createdBefore.CheckValid(); err != nil {
+			return models.DeadLetterFilter{}, status.Error(codes.InvalidArgument, "invalid created_before")
+		}
+		filter.CreatedBefore = createdBefore.AsTime()
+	}
+
+	return filter, nil
+}
+
+func toProtoDeadLetter(deadLetter models.DeadLetter) *pb.DeadLetter {
+	return &pb.DeadLetter{
+		Id:        deadLetter.ID,
+		Source:    toProtoDeadLetterSource(deadLetter.Source),
+		EventId:   deadLetter.EventID,
+		WebhookId: deadLetter.WebhookID,
+		Payload:   deadLetter.Payload,
+		Reason:    deadLetter.Reason,
+		LastError: deadLetter.LastError,
+		Attempts:  int32(deadLetter.Attempts),
+		CreatedAt: timestamppb.New(deadLetter.CreatedAt),
+	}
+}
+
+func fromProtoDeadLetterSource(source pb.DeadLetterSource) (models.DeadLetterSource, error) {
+	switch source {
+	case pb.DeadLetterSource_DEAD_LETTER_SOURCE_OUTBOX:
+		return models.DeadLetterSourceOutbox, nil
+	case pb.DeadLetterSource_DEAD_LETTER_SOURCE_CONSUMER:
+		return models.DeadLetterSourceConsumer, nil
+	default:
+		return "", status.Error(codes.InvalidArgument, "invalid source")
+	}
+}
+
+func toProtoDeadLetterSource(source models.DeadLetterSource) pb.DeadLetterSource {
+	switch source {
+	case models.DeadLetterSourceOutbox:
+		return pb.DeadLetterSource_DEAD_LETTER_SOURCE_OUTBOX
+	case models.DeadLetterSourceConsumer:
+		return pb.DeadLetterSource_DEAD_LETTER_SOURCE_CONSUMER
+	default:
+		return pb.DeadLetterSource_DEAD_LETTER_SOURCE_UNSPECIFIED
+	}
+}
@@ -5,39 +5,237 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	pb "hookify/gen/hookify"
 	"hookify/internal/models"
 	"hookify/internal/services/hookify"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type apiMock struct {
-	createID     int64
-	createSecret string
-	createErr    error
-	createURL    string
+	createID            int64
+	createSecret        string
+	createAPIKey        string
+	createErr           error
+	createURL           string
+	createApplicationID int64
 
-	submitID      int64
-	submitErr     error
-	submitHookID  int64
-	submitSecret  string
-	submitPayload string
+	application    models.Application
+	applicationErr error
+	message        models.Message
+	deliveries     []models.RawEvent
+	publishErr     error
+	publishAPIKey  string
+
+	submitID             int64
+	submitErr            error
+	submitHookID         int64
+	submitAPIKey         string
+	submitIdempotencyKey string
+	submitDuplicate      bool
+	submitPayload        string
+	submitEventType      string
+
+	batchSubmissions []models.EventSubmission
+	batchCalls       int
+	batchErr         error
+
+	getWebhook models.Webhook
+	getErr     error
+
+	listAfterID int64
+	listLimit   int
+	listResult  []models.Webhook
+	listErr     error
+
+	updateFields models.WebhookUpdate
+	updateResult models.Webhook
+	updateErr    error
+
+	deleteID  int64
+	deleteErr error
+
+	statusWebhook models.Webhook
+	statusErr     error
+
+	rotateGrace     *time.Duration
+	rotateSecret    string
+	rotateExpiresAt time.Time
+	rotateErr       error
+
+	rotateKeyGrace     *time.Duration
+	rotateKey          string
+	rotateKeyExpiresAt time.Time
+	rotateKeyErr       error
+
+	getEvent       models.RawEvent
+	getEventErr    error
+	listFilter     models.EventFilter
+	listBeforeID   int64
+	listEvents     []models.RawEvent
+	listEventsErr  error
+	listEventLimit int
+
+	attemptsEventID int64
+	attemptsAfterID int64
+	attempts        []models.DeliveryAttempt
+
+	redeliverErr error
+	replayFilter models.EventFilter
+	replayRate   float64
+	replayCount  int
+	replayErr    error
+
+	deadLetterFilter models.DeadLetterFilter
+	deadLetters      []models.DeadLetter
+	requeueResult    models.DeadLetter
+	requeueErr       error
+	purgeFilter      models.DeadLetterFilter
+	purged           int64
 }
 
-func (m *apiMock) CreateWebhook(ctx context.Context, url string) (int64, string, error) {
+func (m *apiMock) CreateWebhook(ctx context.Context, url string, applicationID int64) (int64, string, string, error) {
 	m.createURL = url
-	return m.createID, m.createSecret, m.createErr
+	m.createApplicationID = applicationID
+	return m.createID, m.createSecret, m.createAPIKey, m.createErr
+}
+
+func (m *apiMock) CreateApplication(ctx context.Context, name string) (int64, string, error) {
+	return m.application.ID, "app-key", m.applicationErr
+}
+
+func (m *apiMock) GetApplication(ctx context.Context, applicationID int64) (models.Application, error) {
+	return m.application, m.applicationErr
+}
+
+func (m *apiMock) DeleteApplication(ctx context.Context, applicationID int64) error {
+	return m.applicationErr
+}
+
+func (m *apiMock) PublishMessage(ctx context.Context, applicationID int64, eventType string, payload string, apiKey string) (models.Message, []models.RawEvent, error) {
+	m.publishAPIKey = apiKey
+	return m.message, m.deliveries, m.publishErr
+}
+
+func (m *apiMock) GetMessage(ctx context.Context, messageID int64) (models.Message, error) {
+	return m.message, m.applicationErr
+}
+
+func (m *apiMock) GetWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	return m.getWebhook, m.getErr
+}
+
+func (m *apiMock) ListWebhooks(ctx context.Context, afterID int64, limit int) ([]models.Webhook, error) {
+	m.listAfterID = afterID
+	m.listLimit = limit
+	return m.listResult, m.listErr
 }
 
-func (m *apiMock) SubmitEvent(ctx context.Context, webhookID int64, payload string, secret string) (int64, error) {
+func (m *apiMock) UpdateWebhook(ctx context.Context, webhookID int64, update models.WebhookUpdate) (models.Webhook, error) {
+	m.updateFields = update
+	return m.updateResult, m.updateErr
+}
+
+func (m *apiMock) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	m.deleteID = webhookID
+	return m.deleteErr
+}
+
+func (m *apiMock) PauseWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	return m.statusWebhook, m.statusErr
+}
+
+func (m *apiMock) ResumeWebhook(ctx context.Context, webhookID int64) (models.Webhook, error) {
+	return m.statusWebhook, m.statusErr
+}
+
+func (m *apiMock) RotateWebhookSecret(ctx context.Context, webhookID int64, gracePeriod *time.Duration) (string, time.Time, error) {
+	m.rotateGrace = gracePeriod
+	return m.rotateSecret, m.rotateExpiresAt, m.rotateErr
+}
+
+func (m *apiMock) RotateWebhookAPIKey(ctx context.Context, webhookID int64, gracePeriod *time.Duration) (string, time.Time, error) {
+	m.rotateKeyGrace = gracePeriod
+	return m.rotateKey, m.rotateKeyExpiresAt, m.rotateKeyErr
+}
+
+func (m *apiMock) SubmitEvent(ctx context.Context, webhookID int64, eventType string, payload string, apiKey string, idempotencyKey string) (int64, bool, error) {
 	m.submitHookID = webhookID
+	m.submitEventType = eventType
 	m.submitPayload = payload
-	m.submitSecret = secret
-	return m.submitID, m.submitErr
+	m.submitAPIKey = apiKey
+	m.submitIdempotencyKey = idempotencyKey
+	return m.submitID, !m.submitDuplicate, m.submitErr
+}
+
+func (m *apiMock) SubmitEvents(ctx context.Context, submissions []models.EventSubmission) ([]models.SubmissionResult, error) {
+	m.batchCalls++
+	m.batchSubmissions = append(m.batchSubmissions, submissions...)
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+	results := make([]models.SubmissionResult, len(submissions))
+	for i, sub := range submissions {
+		if sub.WebhookID == 404 {
+			results[i].Err = models.ErrWebhookNotFound
+			continue
+		}
+		results[i] = models.SubmissionResult{EventID: int64(len(m.batchSubmissions) - len(submissions) + i + 1), Created: true}
+	}
+	return results, nil
+}
+
+func (m *apiMock) GetEvent(ctx context.Context, eventID int64) (models.RawEvent, error) {
+	return m.getEvent, m.getEventErr
+}
+
+func (m *apiMock) ListEvents(ctx context.Context, filter models.EventFilter, beforeID int64, limit int) ([]models.RawEvent, error) {
+	m.listFilter = filter
+	m.listBeforeID = beforeID
+	m.listEventLimit = limit
+	return m.listEvents, m.listEventsErr
+}
+
+func (m *apiMock) ListDeliveryAttempts(ctx context.Context, eventID int64, afterID int64, limit int) ([]models.DeliveryAttempt, error) {
+	m.attemptsEventID = eventID
+	m.attemptsAfterID = afterID
+	return m.attempts, nil
+}
+
+func (m *apiMock) RedeliverEvent(ctx context.Context, eventID int64) error {
+	return m.redeliverErr
+}
+
+func (m *apiMock) ReplayEvents(ctx context.Context, filter models.EventFilter, ratePerSecond float64) (int, error) {
+	m.replayFilter = filter
+	m.replayRate = ratePerSecond
+	return m.replayCount, m.replayErr
+}
+
+func (m *apiMock) ListDeadLetters(ctx context.Context, filter models.DeadLetterFilter, beforeID int64, limit int) ([]models.DeadLetter, error) {
+	m.deadLetterFilter = filter
+	if len(m.deadLetters) > limit {
+		return m.deadLetters[:limit], nil
+	}
+	return m.deadLetters, nil
+}
+
+func (m *apiMock) RequeueDeadLetter(ctx context.Context, id int64) (models.DeadLetter, error) {
+	return m.requeueResult, m.requeueErr
+}
+
+func (m *apiMock) PurgeDeadLetters(ctx context.Context, filter models.DeadLetterFilter) (int64, error) {
+	m.purgeFilter = filter
+	return m.purged, nil
 }
 
 func TestCreateWebhook_EmptyURL(t *testing.T) {
@@ -57,13 +255,13 @@ func TestCreateWebhook_InvalidURL(t *testing.T) {
 }
 
 func TestCreateWebhook_OK(t *testing.T) {
-	api := &apiMock{createID: 10, createSecret: "sec"}
+	api := &apiMock{createID: 10, createSecret: "sec", createAPIKey: "key"}
 	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
 	resp, err := s.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{Url: "https://example.com"})
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
-	if resp.WebhookId != 10 || resp.Secret != "sec" {
+	if resp.WebhookId != 10 || resp.Secret != "sec" || resp.ApiKey != "key" {
 		t.Fatalf("unexpected response: %#v", resp)
 	}
 	if api.createURL != "https://example.com" {
@@ -71,9 +269,82 @@ func TestCreateWebhook_OK(t *testing.T) {
 	}
 }
 
-func TestSubmitEvent_EmptySecret(t *testing.T) {
+func TestCreateWebhook_ApplicationNotFound(t *testing.T) {
+	api := &apiMock{createErr: models.ErrApplicationNotFound}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{Url: "https://example.com", ApplicationId: 3})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if api.createApplicationID != 3 {
+		t.Fatalf("expected application id to be passed through, got %d", api.createApplicationID)
+	}
+}
+
+func TestCreateApplication_NameRequired(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.CreateApplication(context.Background(), &pb.CreateApplicationRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetApplication_NotFound(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{applicationErr: models.ErrApplicationNotFound}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.GetApplication(context.Background(), &pb.GetApplicationRequest{ApplicationId: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestPublishMessage_OK(t *testing.T) {
+	api := &apiMock{
+		message: models.Message{ID: 9, ApplicationID: 3},
+		deliveries: []models.RawEvent{
+			{ID: 20, WebhookID: 1, MessageID: 9, Status: models.EventStatusPending},
+			{ID: 21, WebhookID: 2, MessageID: 9, Status: models.EventStatusPending},
+		},
+	}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	resp, err := s.PublishMessage(context.Background(), &pb.PublishMessageRequest{ApplicationId: 3, ApiKey: "key", EventType: "invoice.paid", Payload: `{}`})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.MessageId != 9 || len(resp.Deliveries) != 2 || resp.Deliveries[1].WebhookId != 2 || resp.Deliveries[1].MessageId != 9 {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+	if api.publishAPIKey != "key" {
+		t.Fatalf("expected api key to be passed through, got %q", api.publishAPIKey)
+	}
+}
+
+func TestPublishMessage_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *pb.PublishMessageRequest
+		err  error
+		want codes.Code
+	}{
+		{"missing api key", &pb.PublishMessageRequest{ApplicationId: 3}, nil, codes.InvalidArgument},
+		{"wildcard type", &pb.PublishMessageRequest{ApplicationId: 3, ApiKey: "k", EventType: "a.*"}, nil, codes.InvalidArgument},
+		{"unknown application", &pb.PublishMessageRequest{ApplicationId: 3, ApiKey: "k"}, models.ErrApplicationNotFound, codes.NotFound},
+		{"wrong key", &pb.PublishMessageRequest{ApplicationId: 3, ApiKey: "k"}, hookify.ErrInvalidAPIKey, codes.Unauthenticated},
+		{"bad payload", &pb.PublishMessageRequest{ApplicationId: 3, ApiKey: "k"}, hookify.ErrInvalidPayload, codes.InvalidArgument},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := &serverAPI{webhookAPI: &apiMock{publishErr: c.err}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+			_, err := s.PublishMessage(context.Background(), c.req)
+			if status.Code(err) != c.want {
+				t.Fatalf("expected %v, got %v", c.want, err)
+			}
+		})
+	}
+}
+
+func TestSubmitEvent_EmptyAPIKey(t *testing.T) {
 	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
-	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, Secret: ""})
+	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: ""})
 	if status.Code(err) != codes.InvalidArgument {
 		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
 	}
@@ -82,16 +353,16 @@ func TestSubmitEvent_EmptySecret(t *testing.T) {
 func TestSubmitEvent_WebhookNotFound(t *testing.T) {
 	api := &apiMock{submitErr: models.ErrWebhookNotFound}
 	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
-	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, Secret: "s"})
+	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "s"})
 	if status.Code(err) != codes.NotFound {
 		t.Fatalf("expected NotFound, got %v", status.Code(err))
 	}
 }
 
-func TestSubmitEvent_InvalidWebhookSecret(t *testing.T) {
-	api := &apiMock{submitErr: hookify.ErrInvalidWebhookSecret}
+func TestSubmitEvent_InvalidAPIKey(t *testing.T) {
+	api := &apiMock{submitErr: hookify.ErrInvalidAPIKey}
 	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
-	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, Secret: "s"})
+	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "s"})
 	if status.Code(err) != codes.Unauthenticated {
 		t.Fatalf("expected Unauthenticated, got %v", status.Code(err))
 	}
@@ -100,7 +371,7 @@ func TestSubmitEvent_InvalidWebhookSecret(t *testing.T) {
 func TestSubmitEvent_InternalError(t *testing.T) {
 	api := &apiMock{submitErr: errors.New("boom")}
 	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
-	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, Secret: "s"})
+	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "s"})
 	if status.Code(err) != codes.Internal {
 		t.Fatalf("expected Internal, got %v", status.Code(err))
 	}
@@ -109,14 +380,663 @@ func TestSubmitEvent_InternalError(t *testing.T) {
 func TestSubmitEvent_OK(t *testing.T) {
 	api := &apiMock{submitID: 55}
 	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
-	resp, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 7, Payload: `{"a":1}`, Secret: "sec"})
+	resp, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 7, Payload: `{"a":1}`, ApiKey: "key"})
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
 	if resp.EventId != 55 || !resp.Created {
 		t.Fatalf("unexpected response: %#v", resp)
 	}
-	if api.submitHookID != 7 || api.submitSecret != "sec" || api.submitPayload != `{"a":1}` {
-		t.Fatalf("unexpected args: hookID=%d secret=%q payload=%q", api.submitHookID, api.submitSecret, api.submitPayload)
+	if api.submitHookID != 7 || api.submitAPIKey != "key" || api.submitPayload != `{"a":1}` {
+		t.Fatalf("unexpected args: hookID=%d apiKey=%q payload=%q", api.submitHookID, api.submitAPIKey, api.submitPayload)
+	}
+}
+
+func TestSubmitEvent_DuplicateIdempotencyKey(t *testing.T) {
+	api := &apiMock{submitID: 55, submitDuplicate: true}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	resp, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 7, Payload: `{}`, ApiKey: "key", IdempotencyKey: "order-1"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.EventId != 55 || resp.Created {
+		t.Fatalf("expected original event without created, got %#v", resp)
+	}
+	if api.submitIdempotencyKey != "order-1" {
+		t.Fatalf("expected idempotency key to be passed, got %q", api.submitIdempotencyKey)
+	}
+}
+
+func TestSubmitEvents_ReportsResultPerItem(t *testing.T) {
+	api := &apiMock{}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	resp, err := s.SubmitEvents(context.Background(), &pb.SubmitEventsRequest{Events: []*pb.SubmitEventRequest{
+		{WebhookId: 1, Payload: `{}`, ApiKey: "key"},
+		{WebhookId: 1, Payload: `{}`},
+		{WebhookId: 404, Payload: `{}`, ApiKey: "key"},
+		{WebhookId: 1, Payload: `{}`, ApiKey: "key"},
+	}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	r := resp.Results
+	if len(r) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(r))
+	}
+	if r[0].EventId != 1 || !r[0].Created || r[0].ErrorCode != 0 {
+		t.Fatalf("unexpected first result: %#v", r[0])
+	}
+	if codes.Code(r[1].ErrorCode) != codes.InvalidArgument || r[1].ErrorMessage == "" {
+		t.Fatalf("expected invalid item to be rejected, got %#v", r[1])
+	}
+	if codes.Code(r[2].ErrorCode) != codes.NotFound || r[2].EventId != 0 {
+		t.Fatalf("expected unknown webhook to be NotFound, got %#v", r[2])
+	}
+	if r[3].EventId != 3 || r[3].ErrorCode != 0 {
+		t.Fatalf("unexpected last result: %#v", r[3])
+	}
+	if api.batchCalls != 1 || len(api.batchSubmissions) != 3 {
+		t.Fatalf("expected valid items submitted in one call, got %d calls with %#v", api.batchCalls, api.batchSubmissions)
+	}
+}
+
+func TestSubmitEvents_TooManyEvents(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	events := make([]*pb.SubmitEventRequest, maxSubmitBatchSize+1)
+	_, err := s.SubmitEvents(context.Background(), &pb.SubmitEventsRequest{Events: events})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestSubmitEvents_InternalError(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{batchErr: errors.New("boom")}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.SubmitEvents(context.Background(), &pb.SubmitEventsRequest{Events: []*pb.SubmitEventRequest{{WebhookId: 1, Payload: `{}`, ApiKey: "key"}}})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal, got %v", err)
+	}
+}
+
+// submitStreamMock replays requests to SubmitEventStream and keeps the response.
+type submitStreamMock struct {
+	grpc.ServerStream
+	requests []*pb.SubmitEventRequest
+	resp     *pb.SubmitEventsResponse
+}
+
+func (m *submitStreamMock) Context() context.Context { return context.Background() }
+
+func (m *submitStreamMock) Recv() (*pb.SubmitEventRequest, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := m.requests[0]
+	m.requests = m.requests[1:]
+	return req, nil
+}
+
+func (m *submitStreamMock) SendAndClose(resp *pb.SubmitEventsResponse) error {
+	m.resp = resp
+	return nil
+}
+
+func TestSubmitEventStream_SubmitsInBatches(t *testing.T) {
+	api := &apiMock{}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	stream := &submitStreamMock{}
+	for range maxSubmitBatchSize + 1 {
+		stream.requests = append(stream.requests, &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "key"})
+	}
+
+	if err := s.SubmitEventStream(stream); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.batchCalls != 2 {
+		t.Fatalf("expected 2 batches, got %d", api.batchCalls)
+	}
+	if len(stream.resp.Results) != maxSubmitBatchSize+1 || stream.resp.Results[maxSubmitBatchSize].EventId != maxSubmitBatchSize+1 {
+		t.Fatalf("expected a result per streamed event, got %d", len(stream.resp.Results))
+	}
+}
+
+func TestSubmitEventStream_RejectsTooManyEvents(t *testing.T) {
+	api := &apiMock{}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	stream := &submitStreamMock{}
+	for range maxSubmitStreamSize + 1 {
+		stream.requests = append(stream.requests, &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "key"})
+	}
+
+	err := s.SubmitEventStream(stream)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if stream.resp != nil {
+		t.Fatalf("expected no response, got %d results", len(stream.resp.Results))
+	}
+}
+
+func TestSubmitEvent_IdempotencyKeyTooLong(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "s", IdempotencyKey: strings.Repeat("k", 256)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetWebhook_NotFound(t *testing.T) {
+	api := &apiMock{getErr: models.ErrWebhookNotFound}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.GetWebhook(context.Background(), &pb.GetWebhookRequest{WebhookId: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", status.Code(err))
+	}
+}
+
+func TestGetWebhook_DoesNotExposeSecret(t *testing.T) {
+	api := &apiMock{getWebhook: models.Webhook{ID: 4, URL: "https://example.com", Secret: "sec"}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	resp, err := s.GetWebhook(context.Background(), &pb.GetWebhookRequest{WebhookId: 4})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Webhook.Id != 4 || resp.Webhook.Url != "https://example.com" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestListWebhooks_Pagination(t *testing.T) {
+	api := &apiMock{listResult: []models.Webhook{{ID: 1}, {ID: 2}, {ID: 3}}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	resp, err := s.ListWebhooks(context.Background(), &pb.ListWebhooksRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(resp.Webhooks) != 2 {
+		t.Fatalf("expected 2 webhooks, got %d", len(resp.Webhooks))
+	}
+	if api.listLimit != 3 {
+		t.Fatalf("expected one extra row to be requested, got limit=%d", api.listLimit)
+	}
+	if resp.NextPageToken == "" {
+		t.Fatalf("expected next page token")
+	}
+
+	api.listResult = []models.Webhook{{ID: 3}}
+	resp, err = s.ListWebhooks(context.Background(), &pb.ListWebhooksRequest{PageSize: 2, PageToken: resp.NextPageToken})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.listAfterID != 2 {
+		t.Fatalf("expected cursor afterID=2, got %d", api.listAfterID)
+	}
+	if resp.NextPageToken != "" {
+		t.Fatalf("expected empty next page token on last page, got %q", resp.NextPageToken)
+	}
+}
+
+func TestListWebhooks_InvalidPageToken(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.ListWebhooks(context.Background(), &pb.ListWebhooksRequest{PageToken: "!!"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+func TestUpdateWebhook_InvalidURL(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	bad := "://bad"
+	_, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, Url: &bad})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+func TestUpdateWebhook_OK(t *testing.T) {
+	api := &apiMock{updateResult: models.Webhook{ID: 1, URL: "https://example.com/v2"}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	newURL := "https://example.com/v2"
+	resp, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, Url: &newURL})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Webhook.Url != newURL {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+	if api.updateFields.URL == nil || *api.updateFields.URL != newURL {
+		t.Fatalf("expected url to be passed through, got %#v", api.updateFields)
+	}
+}
+
+func TestDeleteWebhook_NotFound(t *testing.T) {
+	api := &apiMock{deleteErr: models.ErrWebhookNotFound}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.DeleteWebhook(context.Background(), &pb.DeleteWebhookRequest{WebhookId: 9})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", status.Code(err))
+	}
+	if api.deleteID != 9 {
+		t.Fatalf("expected deleteID=9, got %d", api.deleteID)
+	}
+}
+
+func TestRotateWebhookSecret_DefaultGracePeriod(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	api := &apiMock{rotateSecret: "new", rotateExpiresAt: expiresAt}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	resp, err := s.RotateWebhookSecret(context.Background(), &pb.RotateWebhookSecretRequest{WebhookId: 1})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Secret != "new" || !resp.PreviousSecretExpiresAt.AsTime().Equal(expiresAt) {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+	if api.rotateGrace != nil {
+		t.Fatalf("expected nil grace period, got %v", *api.rotateGrace)
+	}
+}
+
+func TestRotateWebhookSecret_NegativeGracePeriod(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.RotateWebhookSecret(context.Background(), &pb.RotateWebhookSecretRequest{WebhookId: 1, GracePeriod: durationpb.New(-time.Second)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+func TestUpdateWebhook_MigratesSignatureScheme(t *testing.T) {
+	api := &apiMock{updateResult: models.Webhook{ID: 1, SignatureScheme: models.SignatureSchemeStandard}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	resp, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, SignatureScheme: pb.SignatureScheme_SIGNATURE_SCHEME_STANDARD})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.updateFields.URL != nil {
+		t.Fatalf("expected url to be left unchanged")
+	}
+	if api.updateFields.SignatureScheme == nil || *api.updateFields.SignatureScheme != models.SignatureSchemeStandard {
+		t.Fatalf("expected standard signature scheme, got %#v", api.updateFields.SignatureScheme)
+	}
+	if resp.Webhook.SignatureScheme != pb.SignatureScheme_SIGNATURE_SCHEME_STANDARD {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestUpdateWebhook_EventTypes(t *testing.T) {
+	api := &apiMock{updateResult: models.Webhook{ID: 1, EventTypes: []string{"invoice.*"}}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	resp, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, EventTypes: &pb.EventTypes{Patterns: []string{"invoice.*"}}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.updateFields.EventTypes == nil || len(*api.updateFields.EventTypes) != 1 || (*api.updateFields.EventTypes)[0] != "invoice.*" {
+		t.Fatalf("expected subscriptions to be passed through, got %#v", api.updateFields.EventTypes)
+	}
+	if len(resp.Webhook.EventTypes) != 1 || resp.Webhook.EventTypes[0] != "invoice.*" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+
+	if _, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.updateFields.EventTypes != nil {
+		t.Fatalf("expected subscriptions to be left unchanged")
+	}
+
+	_, err = s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, EventTypes: &pb.EventTypes{Patterns: []string{"*.paid"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestSubmitEvent_EventType(t *testing.T) {
+	api := &apiMock{submitID: 1}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	if _, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "key", EventType: "invoice.paid"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.submitEventType != "invoice.paid" {
+		t.Fatalf("expected event type to be passed, got %q", api.submitEventType)
+	}
+
+	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "key", EventType: "invoice.*"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a wildcard event type, got %v", err)
+	}
+}
+
+func TestUpdateWebhook_Ordered(t *testing.T) {
+	api := &apiMock{updateResult: models.Webhook{ID: 1, Ordered: true}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	ordered := true
+	resp, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, Ordered: &ordered})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.updateFields.Ordered == nil || !*api.updateFields.Ordered {
+		t.Fatalf("expected ordered to be set, got %#v", api.updateFields.Ordered)
+	}
+	if !resp.Webhook.Ordered {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestRotateWebhookApiKey_NotFound(t *testing.T) {
+	api := &apiMock{rotateKeyErr: models.ErrWebhookNotFound}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.RotateWebhookApiKey(context.Background(), &pb.RotateWebhookApiKeyRequest{WebhookId: 1, GracePeriod: durationpb.New(time.Minute)})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", status.Code(err))
+	}
+	if api.rotateKeyGrace == nil || *api.rotateKeyGrace != time.Minute {
+		t.Fatalf("expected grace period to be passed through, got %v", api.rotateKeyGrace)
+	}
+}
+
+func TestGetEvent_NotFound(t *testing.T) {
+	api := &apiMock{getEventErr: models.ErrEventNotFound}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.GetEvent(context.Background(), &pb.GetEventRequest{EventId: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", status.Code(err))
+	}
+}
+
+func TestGetEvent_OK(t *testing.T) {
+	api := &apiMock{getEvent: models.RawEvent{ID: 5, WebhookID: 2, Payload: `{}`, Status: models.EventStatusDelivered}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	resp, err := s.GetEvent(context.Background(), &pb.GetEventRequest{EventId: 5})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Event.Id != 5 || resp.Event.Status != pb.EventStatus_EVENT_STATUS_DELIVERED {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestListEvents_FiltersAndPaginates(t *testing.T) {
+	api := &apiMock{listEvents: []models.RawEvent{{ID: 9}, {ID: 8}}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp, err := s.ListEvents(context.Background(), &pb.ListEventsRequest{
+		WebhookId:    3,
+		Status:       pb.EventStatus_EVENT_STATUS_FAILED,
+		CreatedAfter: timestamppb.New(after),
+		PageSize:     1,
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	want := models.EventFilter{WebhookID: 3, Status: models.EventStatusFailed, CreatedAfter: after}
+	if api.listFilter != want {
+		t.Fatalf("unexpected filter: %#v", api.listFilter)
+	}
+	if len(resp.Events) != 1 || resp.NextPageToken == "" {
+		t.Fatalf("expected one event and a next page token, got %#v", resp)
+	}
+
+	if _, err := s.ListEvents(context.Background(), &pb.ListEventsRequest{PageToken: resp.NextPageToken}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.listBeforeID != 9 {
+		t.Fatalf("expected cursor beforeID=9, got %d", api.listBeforeID)
+	}
+}
+
+func TestListEvents_InvalidTimeRange(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	now := time.Now()
+	_, err := s.ListEvents(context.Background(), &pb.ListEventsRequest{
+		CreatedAfter:  timestamppb.New(now),
+		CreatedBefore: timestamppb.New(now.Add(-time.Hour)),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+func TestListDeliveryAttempts_RequiresEventID(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.ListDeliveryAttempts(context.Background(), &pb.ListDeliveryAttemptsRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+func TestListDeliveryAttempts_OK(t *testing.T) {
+	api := &apiMock{attempts: []models.DeliveryAttempt{
+		{ID: 1, EventID: 4, Attempt: 1, StatusCode: 503, Latency: 120 * time.Millisecond, Error: "received non-2xx response: 503"},
+		{ID: 2, EventID: 4, Attempt: 2, StatusCode: 200, Latency: 80 * time.Millisecond},
+	}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	resp, err := s.ListDeliveryAttempts(context.Background(), &pb.ListDeliveryAttemptsRequest{EventId: 4})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.attemptsEventID != 4 {
+		t.Fatalf("expected event id to be passed through, got %d", api.attemptsEventID)
+	}
+	if len(resp.Attempts) != 2 || resp.Attempts[0].StatusCode != 503 || resp.Attempts[1].Latency.AsDuration() != 80*time.Millisecond {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestRedeliverEvent_NotFound(t *testing.T) {
+	api := &apiMock{redeliverErr: models.ErrEventNotFound}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.RedeliverEvent(context.Background(), &pb.RedeliverEventRequest{EventId: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", status.Code(err))
+	}
+}
+
+func TestReplayEvents_RequiresWebhookID(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.ReplayEvents(context.Background(), &pb.ReplayEventsRequest{Status: pb.EventStatus_EVENT_STATUS_FAILED})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+func TestReplayEvents_RateOutOfRange(t *testing.T) {
+	for _, rate := range []float64{-1, 1e-12, math.NaN(), models.MaxReplayRate * 2} {
+		s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+		_, err := s.ReplayEvents(context.Background(), &pb.ReplayEventsRequest{WebhookId: 2, RatePerSecond: rate})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for rate %v, got %v", rate, err)
+		}
+	}
+}
+
+func TestReplayEvents_ReportsScheduledOnFailure(t *testing.T) {
+	api := &apiMock{replayCount: 500, replayErr: errors.New("db down")}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.ReplayEvents(context.Background(), &pb.ReplayEventsRequest{WebhookId: 2})
+	if status.Code(err) != codes.Internal || !strings.Contains(status.Convert(err).Message(), "500") {
+		t.Fatalf("expected Internal reporting 500 scheduled events, got %v", err)
+	}
+}
+
+func TestReplayEvents_OK(t *testing.T) {
+	api := &apiMock{replayCount: 12}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	resp, err := s.ReplayEvents(context.Background(), &pb.ReplayEventsRequest{WebhookId: 2, Status: pb.EventStatus_EVENT_STATUS_FAILED, RatePerSecond: 5})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Scheduled != 12 {
+		t.Fatalf("expected scheduled=12, got %d", resp.Scheduled)
+	}
+	if api.replayFilter.WebhookID != 2 || api.replayFilter.Status != models.EventStatusFailed || api.replayRate != 5 {
+		t.Fatalf("unexpected args: filter=%#v rate=%v", api.replayFilter, api.replayRate)
+	}
+}
+
+func TestListDeadLetters_FiltersAndPaginates(t *testing.T) {
+	api := &apiMock{deadLetters: []models.DeadLetter{
+		{ID: 3, Source: models.DeadLetterSourceConsumer},
+		{ID: 2, Source: models.DeadLetterSourceConsumer},
+	}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	resp, err := s.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{Source: pb.DeadLetterSource_DEAD_LETTER_SOURCE_CONSUMER, PageSize: 1})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if api.deadLetterFilter.Source != models.DeadLetterSourceConsumer {
+		t.Fatalf("unexpected filter %#v", api.deadLetterFilter)
+	}
+	if len(resp.DeadLetters) != 1 || resp.DeadLetters[0].Source != pb.DeadLetterSource_DEAD_LETTER_SOURCE_CONSUMER || resp.NextPageToken == "" {
+		t.Fatalf("unexpected response %#v", resp)
+	}
+}
+
+func TestRequeueDeadLetter_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{models.ErrDeadLetterNotFound, codes.NotFound},
+		{models.ErrWebhookNotFound, codes.NotFound},
+		{models.ErrEventNotFound, codes.NotFound},
+		{hookify.ErrDeadLetterNotRequeueable, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		s := &serverAPI{webhookAPI: &apiMock{requeueErr: tt.err}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+		_, err := s.RequeueDeadLetter(context.Background(), &pb.RequeueDeadLetterRequest{DeadLetterId: 1})
+		if status.Code(err) != tt.want {
+			t.Errorf("%v: expected %v, got %v", tt.err, tt.want, status.Code(err))
+		}
+	}
+}
+
+func TestPurgeDeadLetters_OK(t *testing.T) {
+	api := &apiMock{purged: 4}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp, err := s.PurgeDeadLetters(context.Background(), &pb.PurgeDeadLettersRequest{WebhookId: 2, CreatedBefore: timestamppb.New(before)})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Purged != 4 {
+		t.Fatalf("expected purged=4, got %d", resp.Purged)
+	}
+	if api.purgeFilter.WebhookID != 2 || !api.purgeFilter.CreatedBefore.Equal(before) {
+		t.Fatalf("unexpected filter %#v", api.purgeFilter)
+	}
+}
+
+func TestPurgeDeadLetters_RequiresFilterOrAll(t *testing.T) {
+	api := &apiMock{purged: 9}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	_, err := s.PurgeDeadLetters(context.Background(), &pb.PurgeDeadLettersRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+
+	resp, err := s.PurgeDeadLetters(context.Background(), &pb.PurgeDeadLettersRequest{All: true})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Purged != 9 || api.purgeFilter != (models.DeadLetterFilter{}) {
+		t.Fatalf("unexpected purge: purged=%d filter=%#v", resp.Purged, api.purgeFilter)
+	}
+}
+
+func TestUpdateWebhook_RefusesLegacyScheme(t *testing.T) {
+	api := &apiMock{}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	_, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, SignatureScheme: pb.SignatureScheme_SIGNATURE_SCHEME_LEGACY})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if api.updateFields.SignatureScheme != nil {
+		t.Fatalf("expected the webhook not to be updated")
+	}
+}
+
+func TestUpdateWebhook_RetryPolicy(t *testing.T) {
+	api := &apiMock{}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	_, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, RetryPolicy: &pb.RetryPolicy{
+		InitialInterval: durationpb.New(time.Second),
+		Multiplier:      2,
+		MaxInterval:     durationpb.New(time.Minute),
+		Jitter:          0.1,
+		MaxAttempts:     5,
+		MaxAge:          durationpb.New(time.Hour),
+	}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	want := models.RetryPolicy{InitialInterval: time.Second, Multiplier: 2, MaxInterval: time.Minute, Jitter: 0.1, MaxAttempts: 5, MaxAge: time.Hour}
+	if api.updateFields.RetryPolicy == nil || *api.updateFields.RetryPolicy != want {
+		t.Fatalf("unexpected retry policy: %#v", api.updateFields.RetryPolicy)
+	}
+}
+
+func TestUpdateWebhook_InvalidRetryPolicy(t *testing.T) {
+	s := &serverAPI{webhookAPI: &apiMock{}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.UpdateWebhook(context.Background(), &pb.UpdateWebhookRequest{WebhookId: 1, RetryPolicy: &pb.RetryPolicy{Multiplier: 2}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+func TestSubmitEvent_WebhookDisabled(t *testing.T) {
+	api := &apiMock{submitErr: hookify.ErrWebhookDisabled}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	_, err := s.SubmitEvent(context.Background(), &pb.SubmitEventRequest{WebhookId: 1, Payload: `{}`, ApiKey: "s"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", status.Code(err))
+	}
+}
+
+func TestPauseWebhook_OK(t *testing.T) {
+	api := &apiMock{statusWebhook: models.Webhook{ID: 3, URL: "https://example.com", Status: models.WebhookStatusPaused}}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	resp, err := s.PauseWebhook(context.Background(), &pb.PauseWebhookRequest{WebhookId: 3})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if resp.Webhook.Status != pb.WebhookStatus_WEBHOOK_STATUS_PAUSED {
+		t.Fatalf("expected paused status, got %v", resp.Webhook.Status)
+	}
+}
+
+func TestResumeWebhook_NotFound(t *testing.T) {
+	api := &apiMock{statusErr: models.ErrWebhookNotFound}
+	s := &serverAPI{webhookAPI: api, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	_, err := s.ResumeWebhook(context.Background(), &pb.ResumeWebhookRequest{WebhookId: 3})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", status.Code(err))
+	}
+}
+
+func TestCreateWebhook_RefusesInternalTarget(t *testing.T) {
+	api := &apiMock{}
+	s := &serverAPI{webhookAPI: api, egressPolicy: models.EgressPolicy{AllowedSchemes: []string{"http", "https"}, AllowedPorts: []int{80, 443}}, log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	for _, u := range []string{"http://169.254.169.254/", "http://localhost:5432", "https://example.com:5432/", "ftp://example.com/"} {
+		_, err := s.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{Url: u})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s: expected InvalidArgument, got %v", u, status.Code(err))
+		}
+	}
+	if api.createURL != "" {
+		t.Fatalf("expected no webhook to be created, got %q", api.createURL)
 	}
 }
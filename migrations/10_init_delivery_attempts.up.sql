@@ -0,0 +1,14 @@
+CREATE TABLE delivery_attempts (
+    id SERIAL PRIMARY KEY,
+    event_id INT NOT NULL,
+    webhook_id INT NOT NULL,
+    attempt INT NOT NULL,
+    requested_at TIMESTAMPTZ NOT NULL,
+    status_code INT,
+    response_body TEXT NOT NULL DEFAULT '',
+    latency_ms INT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_delivery_attempts_event_id ON delivery_attempts (event_id, id);
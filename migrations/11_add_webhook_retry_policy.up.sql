@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN retry_policy JSONB;
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN disabled_reason;
+ALTER TABLE webhooks DROP COLUMN status;
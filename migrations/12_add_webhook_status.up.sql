@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'active';
+ALTER TABLE webhooks ADD COLUMN disabled_reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+CREATE TABLE webhook_circuits (
+    webhook_id INT PRIMARY KEY,
+    state VARCHAR(16) NOT NULL DEFAULT 'closed',
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    open_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
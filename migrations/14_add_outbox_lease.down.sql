@@ -0,0 +1,2 @@
+ALTER TABLE outbox DROP COLUMN locked_until;
+ALTER TABLE outbox DROP COLUMN locked_by;
@@ -0,0 +1,14 @@
+CREATE TABLE dead_letters (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(32) NOT NULL,
+    event_id INT,
+    webhook_id INT,
+    type VARCHAR(32) NOT NULL DEFAULT '',
+    payload TEXT NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_dead_letters_webhook_id ON dead_letters (webhook_id, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_events_pending_webhook_sequence;
+ALTER TABLE events DROP COLUMN sequence;
+ALTER TABLE webhooks DROP COLUMN last_sequence;
+ALTER TABLE webhooks DROP COLUMN ordered;
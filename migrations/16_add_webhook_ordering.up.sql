@@ -0,0 +1,13 @@
+ALTER TABLE webhooks ADD COLUMN ordered BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE webhooks ADD COLUMN last_sequence BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN sequence BIGINT NOT NULL DEFAULT 0;
+
+UPDATE events
+SET sequence = numbered.sequence
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY webhook_id ORDER BY id) AS sequence FROM events) AS numbered
+WHERE events.id = numbered.id;
+
+UPDATE webhooks
+SET last_sequence = COALESCE((SELECT MAX(sequence) FROM events WHERE events.webhook_id = webhooks.id), 0);
+
+CREATE INDEX idx_events_pending_webhook_sequence ON events (webhook_id, sequence) WHERE status = 'pending';
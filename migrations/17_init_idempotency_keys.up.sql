@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys (
+    webhook_id INT NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    event_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (webhook_id, key),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
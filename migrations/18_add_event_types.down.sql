@@ -0,0 +1,15 @@
+ALTER TABLE webhooks DROP COLUMN event_types;
+ALTER TABLE outbox DROP COLUMN event_type;
+ALTER TABLE events DROP COLUMN event_type;
+
+-- Enum values cannot be dropped, so the type is recreated without 'filtered'.
+UPDATE events SET status = 'delivered' WHERE status = 'filtered';
+
+DROP INDEX IF EXISTS idx_events_pending_webhook_sequence;
+ALTER TABLE events ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE events ALTER COLUMN status TYPE TEXT;
+DROP TYPE event_status;
+CREATE TYPE event_status AS ENUM ('pending', 'delivered', 'failed');
+ALTER TABLE events ALTER COLUMN status TYPE event_status USING status::event_status;
+ALTER TABLE events ALTER COLUMN status SET DEFAULT 'pending';
+CREATE INDEX idx_events_pending_webhook_sequence ON events (webhook_id, sequence) WHERE status = 'pending';
@@ -0,0 +1,5 @@
+ALTER TYPE event_status ADD VALUE 'filtered';
+
+ALTER TABLE events ADD COLUMN event_type VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE outbox ADD COLUMN event_type VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN event_types TEXT[] NOT NULL DEFAULT '{}';
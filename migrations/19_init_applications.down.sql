@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_events_message_id;
+DROP INDEX IF EXISTS idx_webhooks_application_id;
+ALTER TABLE events DROP COLUMN message_id;
+ALTER TABLE webhooks DROP COLUMN application_id;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS applications;
@@ -0,0 +1,21 @@
+CREATE TABLE applications (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    api_key_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE messages (
+    id SERIAL PRIMARY KEY,
+    application_id INT NOT NULL,
+    event_type VARCHAR(255) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE CASCADE
+);
+
+ALTER TABLE webhooks ADD COLUMN application_id INT REFERENCES applications(id) ON DELETE CASCADE;
+ALTER TABLE events ADD COLUMN message_id INT REFERENCES messages(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_webhooks_application_id ON webhooks (application_id);
+CREATE INDEX idx_events_message_id ON events (message_id);
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN signature_scheme VARCHAR(32) NOT NULL DEFAULT 'legacy';
+ALTER TABLE webhooks ALTER COLUMN signature_scheme SET DEFAULT 'standard';
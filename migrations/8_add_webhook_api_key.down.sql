@@ -0,0 +1,6 @@
+-- Discards every issued API key. Applying the up migration again restores the
+-- webhook secret as the previous key for a new grace window, so publishers
+-- must rotate their API keys again afterwards.
+ALTER TABLE webhooks DROP COLUMN previous_api_key_expires_at;
+ALTER TABLE webhooks DROP COLUMN previous_api_key_hash;
+ALTER TABLE webhooks DROP COLUMN api_key_hash;
@@ -0,0 +1,15 @@
+-- Existing publishers authenticated with the webhook secret, which receivers
+-- also hold. It is kept only as the previous API key, accepted for a bounded
+-- grace window during which every use is logged; the webhook's API key must be
+-- rotated before the window ends. Until then the current key is a random value
+-- nobody holds.
+ALTER TABLE webhooks ADD COLUMN api_key_hash TEXT;
+ALTER TABLE webhooks ADD COLUMN previous_api_key_hash TEXT;
+ALTER TABLE webhooks ADD COLUMN previous_api_key_expires_at TIMESTAMPTZ;
+
+UPDATE webhooks SET
+    api_key_hash = encode(sha256(convert_to(gen_random_uuid()::text, 'UTF8')), 'hex'),
+    previous_api_key_hash = encode(sha256(convert_to(secret, 'UTF8')), 'hex'),
+    previous_api_key_expires_at = NOW() + INTERVAL '7 days';
+
+ALTER TABLE webhooks ALTER COLUMN api_key_hash SET NOT NULL;
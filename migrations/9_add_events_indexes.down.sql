@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_events_created_at;
+DROP INDEX IF EXISTS idx_events_status_id;
+DROP INDEX IF EXISTS idx_events_webhook_id_id;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_events_webhook_id_id ON events (webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_events_status_id ON events (status, id);
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events (created_at);